@@ -1,478 +1,3390 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.24.0
+// 	protoc        v3.12.3
 // source: vimonade.proto
 
 package vimonade
 
 import (
 	context "context"
-	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	math "math"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
 
 type CopyRequest struct {
-	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value    string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Register string `protobuf:"bytes,2,opt,name=register,proto3" json:"register,omitempty"`
+	// Vim register type: v (charwise), V (linewise) or b{width} (blockwise)
+	Regtype string `protobuf:"bytes,3,opt,name=regtype,proto3" json:"regtype,omitempty"`
+	// the same content in other formats than plain text
+	Payloads []*Payload `protobuf:"bytes,4,rep,name=payloads,proto3" json:"payloads,omitempty"`
+	// X11 selection: clipboard (default), primary or secondary
+	Selection string `protobuf:"bytes,5,opt,name=selection,proto3" json:"selection,omitempty"`
+	// kept out of logs and history, and expires after ttl or 30s
+	Sensitive bool `protobuf:"varint,6,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	// milliseconds after which the previous clipboard content is restored, 0 never expires
+	Ttl int64 `protobuf:"varint,7,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// shared channel to copy to instead of the system clipboard, unless the server bridges it
+	Channel string `protobuf:"bytes,8,opt,name=channel,proto3" json:"channel,omitempty"`
+	// client which made the copy
+	Origin *CopyOrigin `protobuf:"bytes,9,opt,name=origin,proto3" json:"origin,omitempty"`
+}
+
+func (x *CopyRequest) Reset() {
+	*x = CopyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyRequest) ProtoMessage() {}
+
+func (x *CopyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *CopyRequest) Reset()         { *m = CopyRequest{} }
-func (m *CopyRequest) String() string { return proto.CompactTextString(m) }
-func (*CopyRequest) ProtoMessage()    {}
+// Deprecated: Use CopyRequest.ProtoReflect.Descriptor instead.
 func (*CopyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d1d9016bdda1f4a, []int{0}
+	return file_vimonade_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CopyRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
 }
 
-func (m *CopyRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CopyRequest.Unmarshal(m, b)
+func (x *CopyRequest) GetRegister() string {
+	if x != nil {
+		return x.Register
+	}
+	return ""
 }
-func (m *CopyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CopyRequest.Marshal(b, m, deterministic)
+
+func (x *CopyRequest) GetRegtype() string {
+	if x != nil {
+		return x.Regtype
+	}
+	return ""
 }
-func (m *CopyRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CopyRequest.Merge(m, src)
+
+func (x *CopyRequest) GetPayloads() []*Payload {
+	if x != nil {
+		return x.Payloads
+	}
+	return nil
 }
-func (m *CopyRequest) XXX_Size() int {
-	return xxx_messageInfo_CopyRequest.Size(m)
+
+func (x *CopyRequest) GetSelection() string {
+	if x != nil {
+		return x.Selection
+	}
+	return ""
 }
-func (m *CopyRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CopyRequest.DiscardUnknown(m)
+
+func (x *CopyRequest) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
 }
 
-var xxx_messageInfo_CopyRequest proto.InternalMessageInfo
+func (x *CopyRequest) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
 
-func (m *CopyRequest) GetValue() string {
-	if m != nil {
-		return m.Value
+func (x *CopyRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
 	}
 	return ""
 }
 
-type CopyResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *CopyRequest) GetOrigin() *CopyOrigin {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
 }
 
-func (m *CopyResponse) Reset()         { *m = CopyResponse{} }
-func (m *CopyResponse) String() string { return proto.CompactTextString(m) }
-func (*CopyResponse) ProtoMessage()    {}
-func (*CopyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d1d9016bdda1f4a, []int{1}
+// CopyOrigin describes the client which made a copy
+type CopyOrigin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	User     string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// vimonade version of the client
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// unique ID of the copy, so a copy delivered twice is only applied once
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *CopyOrigin) Reset() {
+	*x = CopyOrigin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyOrigin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyOrigin) ProtoMessage() {}
+
+func (x *CopyOrigin) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *CopyResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CopyResponse.Unmarshal(m, b)
+// Deprecated: Use CopyOrigin.ProtoReflect.Descriptor instead.
+func (*CopyOrigin) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{1}
 }
-func (m *CopyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CopyResponse.Marshal(b, m, deterministic)
+
+func (x *CopyOrigin) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
 }
-func (m *CopyResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CopyResponse.Merge(m, src)
+
+func (x *CopyOrigin) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
 }
-func (m *CopyResponse) XXX_Size() int {
-	return xxx_messageInfo_CopyResponse.Size(m)
+
+func (x *CopyOrigin) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
 }
-func (m *CopyResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CopyResponse.DiscardUnknown(m)
+
+func (x *CopyOrigin) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
 }
 
-var xxx_messageInfo_CopyResponse proto.InternalMessageInfo
+type CopyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// version a Paste of the copied register answers
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// the copy is kept out of client caches, as when it contains a secret
+	Sensitive bool `protobuf:"varint,2,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+}
+
+func (x *CopyResponse) Reset() {
+	*x = CopyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-type PasteRequest struct {
-	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *CopyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *PasteRequest) Reset()         { *m = PasteRequest{} }
-func (m *PasteRequest) String() string { return proto.CompactTextString(m) }
-func (*PasteRequest) ProtoMessage()    {}
-func (*PasteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d1d9016bdda1f4a, []int{2}
+func (*CopyResponse) ProtoMessage() {}
+
+func (x *CopyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *PasteRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PasteRequest.Unmarshal(m, b)
+// Deprecated: Use CopyResponse.ProtoReflect.Descriptor instead.
+func (*CopyResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{2}
 }
-func (m *PasteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PasteRequest.Marshal(b, m, deterministic)
+
+func (x *CopyResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
 }
-func (m *PasteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PasteRequest.Merge(m, src)
+
+func (x *CopyResponse) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
 }
-func (m *PasteRequest) XXX_Size() int {
-	return xxx_messageInfo_PasteRequest.Size(m)
+
+type PasteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value    string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Register string `protobuf:"bytes,2,opt,name=register,proto3" json:"register,omitempty"`
+	// 1-based history index, 0 pastes the current register
+	Index uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// format to paste, plain text when empty
+	MimeType string `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// X11 selection: clipboard (default), primary or secondary
+	Selection string `protobuf:"bytes,5,opt,name=selection,proto3" json:"selection,omitempty"`
+	// version of the content the client already has, answered with unchanged if it's still current
+	IfNoneMatch string `protobuf:"bytes,6,opt,name=if_none_match,json=ifNoneMatch,proto3" json:"if_none_match,omitempty"`
+	// shared channel to paste from instead of the system clipboard, unless the server bridges it
+	Channel string `protobuf:"bytes,7,opt,name=channel,proto3" json:"channel,omitempty"`
+}
+
+func (x *PasteRequest) Reset() {
+	*x = PasteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *PasteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PasteRequest.DiscardUnknown(m)
+
+func (x *PasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_PasteRequest proto.InternalMessageInfo
+func (*PasteRequest) ProtoMessage() {}
 
-func (m *PasteRequest) GetValue() string {
-	if m != nil {
-		return m.Value
+func (x *PasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasteRequest.ProtoReflect.Descriptor instead.
+func (*PasteRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PasteRequest) GetValue() string {
+	if x != nil {
+		return x.Value
 	}
 	return ""
 }
 
-type PasteResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *PasteRequest) GetRegister() string {
+	if x != nil {
+		return x.Register
+	}
+	return ""
 }
 
-func (m *PasteResponse) Reset()         { *m = PasteResponse{} }
-func (m *PasteResponse) String() string { return proto.CompactTextString(m) }
-func (*PasteResponse) ProtoMessage()    {}
-func (*PasteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d1d9016bdda1f4a, []int{3}
+func (x *PasteRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PasteRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
 }
 
-func (m *PasteResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PasteResponse.Unmarshal(m, b)
+func (x *PasteRequest) GetSelection() string {
+	if x != nil {
+		return x.Selection
+	}
+	return ""
 }
-func (m *PasteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PasteResponse.Marshal(b, m, deterministic)
+
+func (x *PasteRequest) GetIfNoneMatch() string {
+	if x != nil {
+		return x.IfNoneMatch
+	}
+	return ""
 }
-func (m *PasteResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PasteResponse.Merge(m, src)
+
+func (x *PasteRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
 }
-func (m *PasteResponse) XXX_Size() int {
-	return xxx_messageInfo_PasteResponse.Size(m)
+
+type PasteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value    string     `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Regtype  string     `protobuf:"bytes,2,opt,name=regtype,proto3" json:"regtype,omitempty"`
+	Payloads []*Payload `protobuf:"bytes,3,rep,name=payloads,proto3" json:"payloads,omitempty"`
+	// hash of the content, changing whenever the content does
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// the content matches if_none_match, so neither value nor payloads are sent
+	Unchanged bool `protobuf:"varint,5,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	// the content is a sensitive copy, which must not be cached
+	Sensitive bool `protobuf:"varint,6,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	// unix time in milliseconds the content was copied, or first seen on the host, 0 if unknown
+	CopiedAt int64 `protobuf:"varint,7,opt,name=copied_at,json=copiedAt,proto3" json:"copied_at,omitempty"`
+}
+
+func (x *PasteResponse) Reset() {
+	*x = PasteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *PasteResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PasteResponse.DiscardUnknown(m)
+
+func (x *PasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_PasteResponse proto.InternalMessageInfo
+func (*PasteResponse) ProtoMessage() {}
 
-type SendFileRequest struct {
-	// Types that are valid to be assigned to Data:
-	//	*SendFileRequest_Info
-	//	*SendFileRequest_ChunkData
-	Data                 isSendFileRequest_Data `protobuf_oneof:"data"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+func (x *PasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *SendFileRequest) Reset()         { *m = SendFileRequest{} }
-func (m *SendFileRequest) String() string { return proto.CompactTextString(m) }
-func (*SendFileRequest) ProtoMessage()    {}
-func (*SendFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d1d9016bdda1f4a, []int{4}
+// Deprecated: Use PasteResponse.ProtoReflect.Descriptor instead.
+func (*PasteResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{4}
 }
 
-func (m *SendFileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SendFileRequest.Unmarshal(m, b)
+func (x *PasteResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
 }
-func (m *SendFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SendFileRequest.Marshal(b, m, deterministic)
+
+func (x *PasteResponse) GetRegtype() string {
+	if x != nil {
+		return x.Regtype
+	}
+	return ""
 }
-func (m *SendFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendFileRequest.Merge(m, src)
+
+func (x *PasteResponse) GetPayloads() []*Payload {
+	if x != nil {
+		return x.Payloads
+	}
+	return nil
 }
-func (m *SendFileRequest) XXX_Size() int {
-	return xxx_messageInfo_SendFileRequest.Size(m)
+
+func (x *PasteResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
 }
-func (m *SendFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendFileRequest.DiscardUnknown(m)
+
+func (x *PasteResponse) GetUnchanged() bool {
+	if x != nil {
+		return x.Unchanged
+	}
+	return false
 }
 
-var xxx_messageInfo_SendFileRequest proto.InternalMessageInfo
+func (x *PasteResponse) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
 
-type isSendFileRequest_Data interface {
-	isSendFileRequest_Data()
+func (x *PasteResponse) GetCopiedAt() int64 {
+	if x != nil {
+		return x.CopiedAt
+	}
+	return 0
 }
 
-type SendFileRequest_Info struct {
-	Info *FileInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+// CopyChunk is an info message followed by the chunks of the content.
+// The chunks are the data of the only payload of info if there's one, its value otherwise.
+type CopyChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*CopyChunk_Info
+	//	*CopyChunk_ChunkData
+	Data isCopyChunk_Data `protobuf_oneof:"data"`
 }
 
-type SendFileRequest_ChunkData struct {
-	ChunkData []byte `protobuf:"bytes,2,opt,name=chunk_data,json=chunkData,proto3,oneof"`
+func (x *CopyChunk) Reset() {
+	*x = CopyChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (*SendFileRequest_Info) isSendFileRequest_Data() {}
+func (x *CopyChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*SendFileRequest_ChunkData) isSendFileRequest_Data() {}
+func (*CopyChunk) ProtoMessage() {}
 
-func (m *SendFileRequest) GetData() isSendFileRequest_Data {
+func (x *CopyChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyChunk.ProtoReflect.Descriptor instead.
+func (*CopyChunk) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *CopyChunk) GetData() isCopyChunk_Data {
 	if m != nil {
 		return m.Data
 	}
 	return nil
 }
 
-func (m *SendFileRequest) GetInfo() *FileInfo {
-	if x, ok := m.GetData().(*SendFileRequest_Info); ok {
+func (x *CopyChunk) GetInfo() *CopyRequest {
+	if x, ok := x.GetData().(*CopyChunk_Info); ok {
 		return x.Info
 	}
 	return nil
 }
 
-func (m *SendFileRequest) GetChunkData() []byte {
-	if x, ok := m.GetData().(*SendFileRequest_ChunkData); ok {
+func (x *CopyChunk) GetChunkData() []byte {
+	if x, ok := x.GetData().(*CopyChunk_ChunkData); ok {
 		return x.ChunkData
 	}
 	return nil
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*SendFileRequest) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*SendFileRequest_Info)(nil),
-		(*SendFileRequest_ChunkData)(nil),
-	}
+type isCopyChunk_Data interface {
+	isCopyChunk_Data()
 }
 
-type SendFileResponse struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Size                 uint32   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type CopyChunk_Info struct {
+	Info *CopyRequest `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
 }
 
-func (m *SendFileResponse) Reset()         { *m = SendFileResponse{} }
-func (m *SendFileResponse) String() string { return proto.CompactTextString(m) }
-func (*SendFileResponse) ProtoMessage()    {}
-func (*SendFileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d1d9016bdda1f4a, []int{5}
+type CopyChunk_ChunkData struct {
+	ChunkData []byte `protobuf:"bytes,2,opt,name=chunk_data,json=chunkData,proto3,oneof"`
 }
 
-func (m *SendFileResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SendFileResponse.Unmarshal(m, b)
-}
-func (m *SendFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SendFileResponse.Marshal(b, m, deterministic)
+func (*CopyChunk_Info) isCopyChunk_Data() {}
+
+func (*CopyChunk_ChunkData) isCopyChunk_Data() {}
+
+// PasteChunk is an info message followed by the chunks of the content.
+// The chunks are the data of the only payload of info if there's one, its value otherwise.
+type PasteChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*PasteChunk_Info
+	//	*PasteChunk_ChunkData
+	Data isPasteChunk_Data `protobuf_oneof:"data"`
 }
-func (m *SendFileResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendFileResponse.Merge(m, src)
+
+func (x *PasteChunk) Reset() {
+	*x = PasteChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *SendFileResponse) XXX_Size() int {
-	return xxx_messageInfo_SendFileResponse.Size(m)
+
+func (x *PasteChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *SendFileResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendFileResponse.DiscardUnknown(m)
+
+func (*PasteChunk) ProtoMessage() {}
+
+func (x *PasteChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_SendFileResponse proto.InternalMessageInfo
+// Deprecated: Use PasteChunk.ProtoReflect.Descriptor instead.
+func (*PasteChunk) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{6}
+}
 
-func (m *SendFileResponse) GetName() string {
+func (m *PasteChunk) GetData() isPasteChunk_Data {
 	if m != nil {
-		return m.Name
+		return m.Data
 	}
-	return ""
+	return nil
 }
 
-func (m *SendFileResponse) GetSize() uint32 {
-	if m != nil {
-		return m.Size
+func (x *PasteChunk) GetInfo() *PasteResponse {
+	if x, ok := x.GetData().(*PasteChunk_Info); ok {
+		return x.Info
 	}
-	return 0
+	return nil
 }
 
-type FileInfo struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	FileType             string   `protobuf:"bytes,2,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *PasteChunk) GetChunkData() []byte {
+	if x, ok := x.GetData().(*PasteChunk_ChunkData); ok {
+		return x.ChunkData
+	}
+	return nil
 }
 
-func (m *FileInfo) Reset()         { *m = FileInfo{} }
-func (m *FileInfo) String() string { return proto.CompactTextString(m) }
-func (*FileInfo) ProtoMessage()    {}
-func (*FileInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d1d9016bdda1f4a, []int{6}
+type isPasteChunk_Data interface {
+	isPasteChunk_Data()
 }
 
-func (m *FileInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FileInfo.Unmarshal(m, b)
+type PasteChunk_Info struct {
+	Info *PasteResponse `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
 }
-func (m *FileInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FileInfo.Marshal(b, m, deterministic)
+
+type PasteChunk_ChunkData struct {
+	ChunkData []byte `protobuf:"bytes,2,opt,name=chunk_data,json=chunkData,proto3,oneof"`
 }
-func (m *FileInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FileInfo.Merge(m, src)
+
+func (*PasteChunk_Info) isPasteChunk_Data() {}
+
+func (*PasteChunk_ChunkData) isPasteChunk_Data() {}
+
+type Payload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MimeType string `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Data     []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 }
-func (m *FileInfo) XXX_Size() int {
-	return xxx_messageInfo_FileInfo.Size(m)
+
+func (x *Payload) Reset() {
+	*x = Payload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *FileInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_FileInfo.DiscardUnknown(m)
+
+func (x *Payload) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_FileInfo proto.InternalMessageInfo
+func (*Payload) ProtoMessage() {}
 
-func (m *FileInfo) GetName() string {
-	if m != nil {
-		return m.Name
+func (x *Payload) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (m *FileInfo) GetFileType() string {
-	if m != nil {
-		return m.FileType
+// Deprecated: Use Payload.ProtoReflect.Descriptor instead.
+func (*Payload) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Payload) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*CopyRequest)(nil), "vimonade.CopyRequest")
-	proto.RegisterType((*CopyResponse)(nil), "vimonade.CopyResponse")
-	proto.RegisterType((*PasteRequest)(nil), "vimonade.PasteRequest")
-	proto.RegisterType((*PasteResponse)(nil), "vimonade.PasteResponse")
-	proto.RegisterType((*SendFileRequest)(nil), "vimonade.SendFileRequest")
-	proto.RegisterType((*SendFileResponse)(nil), "vimonade.SendFileResponse")
-	proto.RegisterType((*FileInfo)(nil), "vimonade.FileInfo")
+func (x *Payload) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
 }
 
-func init() {
-	proto.RegisterFile("vimonade.proto", fileDescriptor_4d1d9016bdda1f4a)
+type HistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index    uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Value    string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Register string `protobuf:"bytes,3,opt,name=register,proto3" json:"register,omitempty"`
+	// unix time in seconds
+	CreatedAt int64  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Size      uint64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Regtype   string `protobuf:"bytes,6,opt,name=regtype,proto3" json:"regtype,omitempty"`
+	// exact bytes of the value when it isn't valid UTF-8
+	Data []byte `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+	// client which made the copy
+	Origin *CopyOrigin `protobuf:"bytes,8,opt,name=origin,proto3" json:"origin,omitempty"`
+	// first characters of value on a single line, set instead of value and data by ListHistory
+	Preview string `protobuf:"bytes,9,opt,name=preview,proto3" json:"preview,omitempty"`
+}
+
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var fileDescriptor_4d1d9016bdda1f4a = []byte{
-	// 313 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xcb, 0x4f, 0xc2, 0x40,
-	0x10, 0xc6, 0xa9, 0x29, 0x84, 0x0e, 0x2f, 0xb3, 0xf1, 0x81, 0xf5, 0x20, 0xa9, 0x1e, 0x7a, 0xe2,
-	0x80, 0x07, 0x13, 0x3c, 0xf9, 0x88, 0xc1, 0x9b, 0x59, 0x8c, 0x57, 0xb2, 0xd2, 0x21, 0x6e, 0x84,
-	0xdd, 0x4a, 0xb7, 0x4d, 0xea, 0x7f, 0xe8, 0x7f, 0x65, 0x76, 0xfb, 0x0c, 0x12, 0x6f, 0x3b, 0xdf,
-	0x7c, 0xdf, 0xcc, 0xf4, 0x97, 0x42, 0x3f, 0xe1, 0x1b, 0x29, 0x58, 0x80, 0xe3, 0x70, 0x2b, 0x95,
-	0x24, 0xed, 0xa2, 0xf6, 0x2e, 0xa1, 0xf3, 0x20, 0xc3, 0x94, 0xe2, 0x57, 0x8c, 0x91, 0x22, 0x47,
-	0xd0, 0x4c, 0xd8, 0x3a, 0xc6, 0xa1, 0x35, 0xb2, 0x7c, 0x87, 0x66, 0x85, 0xd7, 0x87, 0x6e, 0x66,
-	0x8a, 0x42, 0x29, 0x22, 0xf4, 0xae, 0xa0, 0xfb, 0xc2, 0x22, 0x85, 0xff, 0xa7, 0x06, 0xd0, 0xcb,
-	0x5d, 0x79, 0x2c, 0x80, 0xc1, 0x1c, 0x45, 0xf0, 0xc4, 0xd7, 0x65, 0xd2, 0x07, 0x9b, 0x8b, 0x95,
-	0x34, 0xc1, 0xce, 0x84, 0x8c, 0xcb, 0x3b, 0xb5, 0xe9, 0x59, 0xac, 0xe4, 0xac, 0x41, 0x8d, 0x83,
-	0x5c, 0x00, 0x2c, 0x3f, 0x62, 0xf1, 0xb9, 0x08, 0x98, 0x62, 0xc3, 0x83, 0x91, 0xe5, 0x77, 0x67,
-	0x0d, 0xea, 0x18, 0xed, 0x91, 0x29, 0x76, 0xdf, 0x02, 0x5b, 0xb7, 0xbc, 0x29, 0x1c, 0x56, 0x5b,
-	0xb2, 0xcd, 0x84, 0x80, 0x2d, 0xd8, 0xa6, 0xb8, 0xcf, 0xbc, 0xb5, 0x16, 0xf1, 0x6f, 0x34, 0xa3,
-	0x7a, 0xd4, 0xbc, 0xbd, 0x5b, 0x68, 0x17, 0x8b, 0xf7, 0x66, 0xce, 0xc1, 0x59, 0xf1, 0x35, 0x2e,
-	0x54, 0x1a, 0x66, 0x41, 0x87, 0xb6, 0xb5, 0xf0, 0x9a, 0x86, 0x38, 0xf9, 0xb1, 0x60, 0xf0, 0x96,
-	0xdf, 0x3f, 0xc7, 0x6d, 0xc2, 0x97, 0x48, 0x6e, 0xc0, 0xd6, 0xe4, 0xc8, 0x71, 0xf5, 0x65, 0x35,
-	0xdc, 0xee, 0xc9, 0xae, 0x9c, 0x93, 0x6a, 0x90, 0x29, 0x34, 0x0d, 0x3c, 0x52, 0xb3, 0xd4, 0x99,
-	0xbb, 0xa7, 0x7f, 0xf4, 0x32, 0x7b, 0x07, 0xb6, 0x26, 0x40, 0xce, 0x2a, 0xcb, 0x0e, 0x77, 0xd7,
-	0xdd, 0xd7, 0x2a, 0x06, 0xf8, 0xd6, 0x7b, 0xcb, 0xfc, 0x27, 0xd7, 0xbf, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x8a, 0xa2, 0x5c, 0xa7, 0x39, 0x02, 0x00, 0x00,
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (*HistoryEntry) ProtoMessage() {}
 
-// VimonadeServiceClient is the client API for VimonadeService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type VimonadeServiceClient interface {
-	Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (*CopyResponse, error)
-	Paste(ctx context.Context, in *PasteRequest, opts ...grpc.CallOption) (*PasteResponse, error)
-	Send(ctx context.Context, opts ...grpc.CallOption) (VimonadeService_SendClient, error)
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type vimonadeServiceClient struct {
-	cc grpc.ClientConnInterface
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{8}
 }
 
-func NewVimonadeServiceClient(cc grpc.ClientConnInterface) VimonadeServiceClient {
-	return &vimonadeServiceClient{cc}
+func (x *HistoryEntry) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
 }
 
-func (c *vimonadeServiceClient) Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (*CopyResponse, error) {
-	out := new(CopyResponse)
-	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/Copy", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *HistoryEntry) GetValue() string {
+	if x != nil {
+		return x.Value
 	}
-	return out, nil
+	return ""
 }
 
-func (c *vimonadeServiceClient) Paste(ctx context.Context, in *PasteRequest, opts ...grpc.CallOption) (*PasteResponse, error) {
-	out := new(PasteResponse)
-	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/Paste", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *HistoryEntry) GetRegister() string {
+	if x != nil {
+		return x.Register
 	}
-	return out, nil
+	return ""
 }
 
-func (c *vimonadeServiceClient) Send(ctx context.Context, opts ...grpc.CallOption) (VimonadeService_SendClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_VimonadeService_serviceDesc.Streams[0], "/vimonade.VimonadeService/Send", opts...)
-	if err != nil {
-		return nil, err
+func (x *HistoryEntry) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
 	}
-	x := &vimonadeServiceSendClient{stream}
-	return x, nil
+	return 0
 }
 
-type VimonadeService_SendClient interface {
-	Send(*SendFileRequest) error
-	CloseAndRecv() (*SendFileResponse, error)
-	grpc.ClientStream
+func (x *HistoryEntry) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
 }
 
-type vimonadeServiceSendClient struct {
-	grpc.ClientStream
+func (x *HistoryEntry) GetRegtype() string {
+	if x != nil {
+		return x.Regtype
+	}
+	return ""
 }
 
-func (x *vimonadeServiceSendClient) Send(m *SendFileRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (x *HistoryEntry) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
 }
 
-func (x *vimonadeServiceSendClient) CloseAndRecv() (*SendFileResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (x *HistoryEntry) GetOrigin() *CopyOrigin {
+	if x != nil {
+		return x.Origin
 	}
-	m := new(SendFileResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	return nil
+}
+
+func (x *HistoryEntry) GetPreview() string {
+	if x != nil {
+		return x.Preview
 	}
-	return m, nil
+	return ""
 }
 
-// VimonadeServiceServer is the server API for VimonadeService service.
+type ListHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListHistoryRequest) Reset() {
+	*x = ListHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHistoryRequest) ProtoMessage() {}
+
+func (x *ListHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{9}
+}
+
+type ListHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*HistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *ListHistoryResponse) Reset() {
+	*x = ListHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHistoryResponse) ProtoMessage() {}
+
+func (x *ListHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListHistoryResponse) GetEntries() []*HistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GetHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetHistoryRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type GetHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entry *HistoryEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetHistoryResponse) GetEntry() *HistoryEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type DeleteHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// clear the whole history, index is ignored
+	All bool `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+}
+
+func (x *DeleteHistoryRequest) Reset() {
+	*x = DeleteHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteHistoryRequest) ProtoMessage() {}
+
+func (x *DeleteHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteHistoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteHistoryRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *DeleteHistoryRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type DeleteHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteHistoryResponse) Reset() {
+	*x = DeleteHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteHistoryResponse) ProtoMessage() {}
+
+func (x *DeleteHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteHistoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{14}
+}
+
+type Snippet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// unix time in seconds
+	UpdatedAt int64  `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Size      uint64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// first characters of data on a single line, set instead of data by ListSnippets
+	Preview string `protobuf:"bytes,5,opt,name=preview,proto3" json:"preview,omitempty"`
+}
+
+func (x *Snippet) Reset() {
+	*x = Snippet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snippet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snippet) ProtoMessage() {}
+
+func (x *Snippet) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snippet.ProtoReflect.Descriptor instead.
+func (*Snippet) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Snippet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Snippet) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Snippet) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Snippet) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Snippet) GetPreview() string {
+	if x != nil {
+		return x.Preview
+	}
+	return ""
+}
+
+type SaveSnippetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *SaveSnippetRequest) Reset() {
+	*x = SaveSnippetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveSnippetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveSnippetRequest) ProtoMessage() {}
+
+func (x *SaveSnippetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveSnippetRequest.ProtoReflect.Descriptor instead.
+func (*SaveSnippetRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SaveSnippetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SaveSnippetRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SaveSnippetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SaveSnippetResponse) Reset() {
+	*x = SaveSnippetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveSnippetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveSnippetResponse) ProtoMessage() {}
+
+func (x *SaveSnippetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveSnippetResponse.ProtoReflect.Descriptor instead.
+func (*SaveSnippetResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{17}
+}
+
+type GetSnippetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetSnippetRequest) Reset() {
+	*x = GetSnippetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSnippetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnippetRequest) ProtoMessage() {}
+
+func (x *GetSnippetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnippetRequest.ProtoReflect.Descriptor instead.
+func (*GetSnippetRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetSnippetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetSnippetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snippet *Snippet `protobuf:"bytes,1,opt,name=snippet,proto3" json:"snippet,omitempty"`
+}
+
+func (x *GetSnippetResponse) Reset() {
+	*x = GetSnippetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSnippetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnippetResponse) ProtoMessage() {}
+
+func (x *GetSnippetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnippetResponse.ProtoReflect.Descriptor instead.
+func (*GetSnippetResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetSnippetResponse) GetSnippet() *Snippet {
+	if x != nil {
+		return x.Snippet
+	}
+	return nil
+}
+
+type ListSnippetsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSnippetsRequest) Reset() {
+	*x = ListSnippetsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSnippetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnippetsRequest) ProtoMessage() {}
+
+func (x *ListSnippetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnippetsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnippetsRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{20}
+}
+
+type ListSnippetsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sorted by name
+	Snippets []*Snippet `protobuf:"bytes,1,rep,name=snippets,proto3" json:"snippets,omitempty"`
+}
+
+func (x *ListSnippetsResponse) Reset() {
+	*x = ListSnippetsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSnippetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnippetsResponse) ProtoMessage() {}
+
+func (x *ListSnippetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnippetsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnippetsResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListSnippetsResponse) GetSnippets() []*Snippet {
+	if x != nil {
+		return x.Snippets
+	}
+	return nil
+}
+
+type DeleteSnippetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DeleteSnippetRequest) Reset() {
+	*x = DeleteSnippetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSnippetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSnippetRequest) ProtoMessage() {}
+
+func (x *DeleteSnippetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSnippetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSnippetRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteSnippetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteSnippetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteSnippetResponse) Reset() {
+	*x = DeleteSnippetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSnippetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSnippetResponse) ProtoMessage() {}
+
+func (x *DeleteSnippetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSnippetResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSnippetResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{23}
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only watch this register, all registers when empty
+	Register string `protobuf:"bytes,1,opt,name=register,proto3" json:"register,omitempty"`
+	// send the sha256 digest of values instead of the values
+	DigestOnly bool `protobuf:"varint,2,opt,name=digest_only,json=digestOnly,proto3" json:"digest_only,omitempty"`
+	// join a shared channel, only watching its copies instead of the system clipboard
+	Channel string `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	// the watching client, which isn't sent the changes it copied itself
+	Origin *CopyOrigin `protobuf:"bytes,4,opt,name=origin,proto3" json:"origin,omitempty"`
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WatchRequest) GetRegister() string {
+	if x != nil {
+		return x.Register
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetDigestOnly() bool {
+	if x != nil {
+		return x.DigestOnly
+	}
+	return false
+}
+
+func (x *WatchRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetOrigin() *CopyOrigin {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+type ClipboardEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Register string `protobuf:"bytes,1,opt,name=register,proto3" json:"register,omitempty"`
+	// left out of copies too large for a single message, which only have their digest and size
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// hex encoded sha256 of value
+	Digest string `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	// peer address of the copy, or "host" for changes made on the server desktop
+	Origin string `protobuf:"bytes,4,opt,name=origin,proto3" json:"origin,omitempty"`
+	// unix time in milliseconds
+	Timestamp int64  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Size      uint64 `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	Regtype   string `protobuf:"bytes,7,opt,name=regtype,proto3" json:"regtype,omitempty"`
+	// number of events dropped since the previous one because the watcher was too slow
+	Dropped uint64 `protobuf:"varint,8,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	// sensitive copies have neither value nor digest
+	Sensitive bool `protobuf:"varint,9,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	// shared channel of the copy, empty for the system clipboard
+	Channel string `protobuf:"bytes,10,opt,name=channel,proto3" json:"channel,omitempty"`
+	// client which made the copy, unset for changes made on the server desktop
+	Client *CopyOrigin `protobuf:"bytes,11,opt,name=client,proto3" json:"client,omitempty"`
+}
+
+func (x *ClipboardEvent) Reset() {
+	*x = ClipboardEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClipboardEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClipboardEvent) ProtoMessage() {}
+
+func (x *ClipboardEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClipboardEvent.ProtoReflect.Descriptor instead.
+func (*ClipboardEvent) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ClipboardEvent) GetRegister() string {
+	if x != nil {
+		return x.Register
+	}
+	return ""
+}
+
+func (x *ClipboardEvent) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ClipboardEvent) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *ClipboardEvent) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *ClipboardEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ClipboardEvent) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ClipboardEvent) GetRegtype() string {
+	if x != nil {
+		return x.Regtype
+	}
+	return ""
+}
+
+func (x *ClipboardEvent) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *ClipboardEvent) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+func (x *ClipboardEvent) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ClipboardEvent) GetClient() *CopyOrigin {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type SendFileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*SendFileRequest_Info
+	//	*SendFileRequest_ChunkData
+	Data isSendFileRequest_Data `protobuf_oneof:"data"`
+}
+
+func (x *SendFileRequest) Reset() {
+	*x = SendFileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendFileRequest) ProtoMessage() {}
+
+func (x *SendFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendFileRequest.ProtoReflect.Descriptor instead.
+func (*SendFileRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{26}
+}
+
+func (m *SendFileRequest) GetData() isSendFileRequest_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *SendFileRequest) GetInfo() *FileInfo {
+	if x, ok := x.GetData().(*SendFileRequest_Info); ok {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *SendFileRequest) GetChunkData() []byte {
+	if x, ok := x.GetData().(*SendFileRequest_ChunkData); ok {
+		return x.ChunkData
+	}
+	return nil
+}
+
+type isSendFileRequest_Data interface {
+	isSendFileRequest_Data()
+}
+
+type SendFileRequest_Info struct {
+	Info *FileInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type SendFileRequest_ChunkData struct {
+	ChunkData []byte `protobuf:"bytes,2,opt,name=chunk_data,json=chunkData,proto3,oneof"`
+}
+
+func (*SendFileRequest_Info) isSendFileRequest_Data() {}
+
+func (*SendFileRequest_ChunkData) isSendFileRequest_Data() {}
+
+type SendFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// hex SHA-256 digest of the stored content
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+}
+
+func (x *SendFileResponse) Reset() {
+	*x = SendFileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendFileResponse) ProtoMessage() {}
+
+func (x *SendFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendFileResponse.ProtoReflect.Descriptor instead.
+func (*SendFileResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SendFileResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SendFileResponse) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SendFileResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type FileInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	FileType string `protobuf:"bytes,2,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
+	// ID of a resumable upload, whose partial file the server keeps until it expires
+	UploadId string `protobuf:"bytes,3,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	// size of the content the chunks follow, which the server must already have
+	Offset uint64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// hex SHA-256 digest of the whole file, which the server checks before storing it
+	Sha256 string `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// size of the whole file, set by Fetch
+	Size uint64 `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FileInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileInfo) GetFileType() string {
+	if x != nil {
+		return x.FileType
+	}
+	return ""
+}
+
+func (x *FileInfo) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *FileInfo) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FileInfo) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *FileInfo) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type UploadStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadId string `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+}
+
+func (x *UploadStatusRequest) Reset() {
+	*x = UploadStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStatusRequest) ProtoMessage() {}
+
+func (x *UploadStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStatusRequest.ProtoReflect.Descriptor instead.
+func (*UploadStatusRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UploadStatusRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type UploadStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadId string `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	// number of bytes received so far
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *UploadStatusResponse) Reset() {
+	*x = UploadStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStatusResponse) ProtoMessage() {}
+
+func (x *UploadStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStatusResponse.ProtoReflect.Descriptor instead.
+func (*UploadStatusResponse) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UploadStatusResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadStatusResponse) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type FetchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the file in the store
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *FetchRequest) Reset() {
+	*x = FetchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRequest) ProtoMessage() {}
+
+func (x *FetchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRequest.ProtoReflect.Descriptor instead.
+func (*FetchRequest) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FetchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FetchChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*FetchChunk_Info
+	//	*FetchChunk_ChunkData
+	Data isFetchChunk_Data `protobuf_oneof:"data"`
+}
+
+func (x *FetchChunk) Reset() {
+	*x = FetchChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vimonade_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchChunk) ProtoMessage() {}
+
+func (x *FetchChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_vimonade_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchChunk.ProtoReflect.Descriptor instead.
+func (*FetchChunk) Descriptor() ([]byte, []int) {
+	return file_vimonade_proto_rawDescGZIP(), []int{32}
+}
+
+func (m *FetchChunk) GetData() isFetchChunk_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *FetchChunk) GetInfo() *FileInfo {
+	if x, ok := x.GetData().(*FetchChunk_Info); ok {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *FetchChunk) GetChunkData() []byte {
+	if x, ok := x.GetData().(*FetchChunk_ChunkData); ok {
+		return x.ChunkData
+	}
+	return nil
+}
+
+type isFetchChunk_Data interface {
+	isFetchChunk_Data()
+}
+
+type FetchChunk_Info struct {
+	Info *FileInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type FetchChunk_ChunkData struct {
+	ChunkData []byte `protobuf:"bytes,2,opt,name=chunk_data,json=chunkData,proto3,oneof"`
+}
+
+func (*FetchChunk_Info) isFetchChunk_Data() {}
+
+func (*FetchChunk_ChunkData) isFetchChunk_Data() {}
+
+var File_vimonade_proto protoreflect.FileDescriptor
+
+var file_vimonade_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x22, 0x9e, 0x02, 0x0a, 0x0b, 0x43,
+	0x6f, 0x70, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x65, 0x67, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72,
+	0x65, 0x67, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e,
+	0x61, 0x64, 0x65, 0x2e, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x08, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x74, 0x74, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x2c, 0x0a,
+	0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x22, 0x75, 0x0a, 0x0a, 0x43,
+	0x6f, 0x70, 0x79, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x64, 0x22, 0x46, 0x0a, 0x0c, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x22, 0xcf, 0x01, 0x0a, 0x0c, 0x50,
+	0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22,
+	0x0a, 0x0d, 0x69, 0x66, 0x5f, 0x6e, 0x6f, 0x6e, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x66, 0x4e, 0x6f, 0x6e, 0x65, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x22, 0xe1, 0x01, 0x0a,
+	0x0d, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2d,
+	0x0a, 0x08, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x52, 0x08, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x75, 0x6e, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74,
+	0x69, 0x76, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x41, 0x74,
+	0x22, 0x61, 0x0a, 0x09, 0x43, 0x6f, 0x70, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2b, 0x0a,
+	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x69,
+	0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x0a, 0x0a, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x42, 0x06, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x64, 0x0a, 0x0a, 0x50, 0x61, 0x73, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x12, 0x2d, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x50, 0x61, 0x73, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f,
+	0x12, 0x1f, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x44, 0x61, 0x74,
+	0x61, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3a, 0x0a, 0x07, 0x50, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xff, 0x01, 0x0a, 0x0c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x2c, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x47, 0x0a,
+	0x13, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65,
+	0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x29, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x22, 0x42, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64,
+	0x65, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05,
+	0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x3e, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x61, 0x6c, 0x6c, 0x22, 0x17, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7e,
+	0x0a, 0x07, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x22, 0x3c,
+	0x0a, 0x12, 0x53, 0x61, 0x76, 0x65, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x15, 0x0a, 0x13,
+	0x53, 0x61, 0x76, 0x65, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x27, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x41, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x53,
+	0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x22,
+	0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x45, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e,
+	0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d,
+	0x0a, 0x08, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x53, 0x6e, 0x69, 0x70,
+	0x70, 0x65, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x22, 0x2a, 0x0a,
+	0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x93, 0x01, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x4f, 0x6e, 0x6c, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x69, 0x6d,
+	0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x22, 0xbe, 0x02, 0x0a, 0x0e, 0x43, 0x6c, 0x69,
+	0x70, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x72, 0x65, 0x67, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x69,
+	0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x22, 0x64, 0x0a, 0x0f, 0x53, 0x65, 0x6e,
+	0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x69, 0x6d,
+	0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00,
+	0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x09, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x52, 0x0a, 0x10, 0x53, 0x65, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x68, 0x61,
+	0x32, 0x35, 0x36, 0x22, 0x9c, 0x01, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x22, 0x32, 0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x22, 0x4b, 0x0a, 0x14, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x22, 0x22, 0x0a, 0x0c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x5f, 0x0a, 0x0a, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x28, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12,
+	0x1f, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61,
+	0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xbf, 0x08, 0x0a, 0x0f, 0x56, 0x69, 0x6d,
+	0x6f, 0x6e, 0x61, 0x64, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x04,
+	0x43, 0x6f, 0x70, 0x79, 0x12, 0x15, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e,
+	0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x69,
+	0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x05, 0x50, 0x61, 0x73, 0x74, 0x65, 0x12, 0x16,
+	0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64,
+	0x65, 0x2e, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x0a, 0x43, 0x6f, 0x70, 0x79, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x13, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x16, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e,
+	0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01,
+	0x12, 0x3f, 0x0a, 0x0b, 0x50, 0x61, 0x73, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x16, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x50, 0x61, 0x73, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61,
+	0x64, 0x65, 0x2e, 0x50, 0x61, 0x73, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x41, 0x0a, 0x04, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x19, 0x2e, 0x76, 0x69, 0x6d, 0x6f,
+	0x6e, 0x61, 0x64, 0x65, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e,
+	0x53, 0x65, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x28, 0x01, 0x12, 0x4f, 0x0a, 0x0c, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x16,
+	0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64,
+	0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x4c, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x1c, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49,
+	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x2e, 0x76,
+	0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x69, 0x6d, 0x6f,
+	0x6e, 0x61, 0x64, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0d, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x2e, 0x76, 0x69, 0x6d,
+	0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x76, 0x69, 0x6d,
+	0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a,
+	0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64,
+	0x65, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x43, 0x6c, 0x69, 0x70, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0b,
+	0x53, 0x61, 0x76, 0x65, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x1c, 0x2e, 0x76, 0x69,
+	0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x6e, 0x69, 0x70, 0x70,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x69, 0x6d, 0x6f,
+	0x6e, 0x61, 0x64, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0a, 0x47, 0x65,
+	0x74, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x1b, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e,
+	0x61, 0x64, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x69,
+	0x70, 0x70, 0x65, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61, 0x64, 0x65, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x1e, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61,
+	0x64, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x76, 0x69, 0x6d, 0x6f, 0x6e, 0x61,
+	0x64, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_vimonade_proto_rawDescOnce sync.Once
+	file_vimonade_proto_rawDescData = file_vimonade_proto_rawDesc
+)
+
+func file_vimonade_proto_rawDescGZIP() []byte {
+	file_vimonade_proto_rawDescOnce.Do(func() {
+		file_vimonade_proto_rawDescData = protoimpl.X.CompressGZIP(file_vimonade_proto_rawDescData)
+	})
+	return file_vimonade_proto_rawDescData
+}
+
+var file_vimonade_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_vimonade_proto_goTypes = []interface{}{
+	(*CopyRequest)(nil),           // 0: vimonade.CopyRequest
+	(*CopyOrigin)(nil),            // 1: vimonade.CopyOrigin
+	(*CopyResponse)(nil),          // 2: vimonade.CopyResponse
+	(*PasteRequest)(nil),          // 3: vimonade.PasteRequest
+	(*PasteResponse)(nil),         // 4: vimonade.PasteResponse
+	(*CopyChunk)(nil),             // 5: vimonade.CopyChunk
+	(*PasteChunk)(nil),            // 6: vimonade.PasteChunk
+	(*Payload)(nil),               // 7: vimonade.Payload
+	(*HistoryEntry)(nil),          // 8: vimonade.HistoryEntry
+	(*ListHistoryRequest)(nil),    // 9: vimonade.ListHistoryRequest
+	(*ListHistoryResponse)(nil),   // 10: vimonade.ListHistoryResponse
+	(*GetHistoryRequest)(nil),     // 11: vimonade.GetHistoryRequest
+	(*GetHistoryResponse)(nil),    // 12: vimonade.GetHistoryResponse
+	(*DeleteHistoryRequest)(nil),  // 13: vimonade.DeleteHistoryRequest
+	(*DeleteHistoryResponse)(nil), // 14: vimonade.DeleteHistoryResponse
+	(*Snippet)(nil),               // 15: vimonade.Snippet
+	(*SaveSnippetRequest)(nil),    // 16: vimonade.SaveSnippetRequest
+	(*SaveSnippetResponse)(nil),   // 17: vimonade.SaveSnippetResponse
+	(*GetSnippetRequest)(nil),     // 18: vimonade.GetSnippetRequest
+	(*GetSnippetResponse)(nil),    // 19: vimonade.GetSnippetResponse
+	(*ListSnippetsRequest)(nil),   // 20: vimonade.ListSnippetsRequest
+	(*ListSnippetsResponse)(nil),  // 21: vimonade.ListSnippetsResponse
+	(*DeleteSnippetRequest)(nil),  // 22: vimonade.DeleteSnippetRequest
+	(*DeleteSnippetResponse)(nil), // 23: vimonade.DeleteSnippetResponse
+	(*WatchRequest)(nil),          // 24: vimonade.WatchRequest
+	(*ClipboardEvent)(nil),        // 25: vimonade.ClipboardEvent
+	(*SendFileRequest)(nil),       // 26: vimonade.SendFileRequest
+	(*SendFileResponse)(nil),      // 27: vimonade.SendFileResponse
+	(*FileInfo)(nil),              // 28: vimonade.FileInfo
+	(*UploadStatusRequest)(nil),   // 29: vimonade.UploadStatusRequest
+	(*UploadStatusResponse)(nil),  // 30: vimonade.UploadStatusResponse
+	(*FetchRequest)(nil),          // 31: vimonade.FetchRequest
+	(*FetchChunk)(nil),            // 32: vimonade.FetchChunk
+}
+var file_vimonade_proto_depIdxs = []int32{
+	7,  // 0: vimonade.CopyRequest.payloads:type_name -> vimonade.Payload
+	1,  // 1: vimonade.CopyRequest.origin:type_name -> vimonade.CopyOrigin
+	7,  // 2: vimonade.PasteResponse.payloads:type_name -> vimonade.Payload
+	0,  // 3: vimonade.CopyChunk.info:type_name -> vimonade.CopyRequest
+	4,  // 4: vimonade.PasteChunk.info:type_name -> vimonade.PasteResponse
+	1,  // 5: vimonade.HistoryEntry.origin:type_name -> vimonade.CopyOrigin
+	8,  // 6: vimonade.ListHistoryResponse.entries:type_name -> vimonade.HistoryEntry
+	8,  // 7: vimonade.GetHistoryResponse.entry:type_name -> vimonade.HistoryEntry
+	15, // 8: vimonade.GetSnippetResponse.snippet:type_name -> vimonade.Snippet
+	15, // 9: vimonade.ListSnippetsResponse.snippets:type_name -> vimonade.Snippet
+	1,  // 10: vimonade.WatchRequest.origin:type_name -> vimonade.CopyOrigin
+	1,  // 11: vimonade.ClipboardEvent.client:type_name -> vimonade.CopyOrigin
+	28, // 12: vimonade.SendFileRequest.info:type_name -> vimonade.FileInfo
+	28, // 13: vimonade.FetchChunk.info:type_name -> vimonade.FileInfo
+	0,  // 14: vimonade.VimonadeService.Copy:input_type -> vimonade.CopyRequest
+	3,  // 15: vimonade.VimonadeService.Paste:input_type -> vimonade.PasteRequest
+	5,  // 16: vimonade.VimonadeService.CopyStream:input_type -> vimonade.CopyChunk
+	3,  // 17: vimonade.VimonadeService.PasteStream:input_type -> vimonade.PasteRequest
+	26, // 18: vimonade.VimonadeService.Send:input_type -> vimonade.SendFileRequest
+	29, // 19: vimonade.VimonadeService.UploadStatus:input_type -> vimonade.UploadStatusRequest
+	31, // 20: vimonade.VimonadeService.Fetch:input_type -> vimonade.FetchRequest
+	9,  // 21: vimonade.VimonadeService.ListHistory:input_type -> vimonade.ListHistoryRequest
+	11, // 22: vimonade.VimonadeService.GetHistory:input_type -> vimonade.GetHistoryRequest
+	13, // 23: vimonade.VimonadeService.DeleteHistory:input_type -> vimonade.DeleteHistoryRequest
+	24, // 24: vimonade.VimonadeService.Watch:input_type -> vimonade.WatchRequest
+	16, // 25: vimonade.VimonadeService.SaveSnippet:input_type -> vimonade.SaveSnippetRequest
+	18, // 26: vimonade.VimonadeService.GetSnippet:input_type -> vimonade.GetSnippetRequest
+	20, // 27: vimonade.VimonadeService.ListSnippets:input_type -> vimonade.ListSnippetsRequest
+	22, // 28: vimonade.VimonadeService.DeleteSnippet:input_type -> vimonade.DeleteSnippetRequest
+	2,  // 29: vimonade.VimonadeService.Copy:output_type -> vimonade.CopyResponse
+	4,  // 30: vimonade.VimonadeService.Paste:output_type -> vimonade.PasteResponse
+	2,  // 31: vimonade.VimonadeService.CopyStream:output_type -> vimonade.CopyResponse
+	6,  // 32: vimonade.VimonadeService.PasteStream:output_type -> vimonade.PasteChunk
+	27, // 33: vimonade.VimonadeService.Send:output_type -> vimonade.SendFileResponse
+	30, // 34: vimonade.VimonadeService.UploadStatus:output_type -> vimonade.UploadStatusResponse
+	32, // 35: vimonade.VimonadeService.Fetch:output_type -> vimonade.FetchChunk
+	10, // 36: vimonade.VimonadeService.ListHistory:output_type -> vimonade.ListHistoryResponse
+	12, // 37: vimonade.VimonadeService.GetHistory:output_type -> vimonade.GetHistoryResponse
+	14, // 38: vimonade.VimonadeService.DeleteHistory:output_type -> vimonade.DeleteHistoryResponse
+	25, // 39: vimonade.VimonadeService.Watch:output_type -> vimonade.ClipboardEvent
+	17, // 40: vimonade.VimonadeService.SaveSnippet:output_type -> vimonade.SaveSnippetResponse
+	19, // 41: vimonade.VimonadeService.GetSnippet:output_type -> vimonade.GetSnippetResponse
+	21, // 42: vimonade.VimonadeService.ListSnippets:output_type -> vimonade.ListSnippetsResponse
+	23, // 43: vimonade.VimonadeService.DeleteSnippet:output_type -> vimonade.DeleteSnippetResponse
+	29, // [29:44] is the sub-list for method output_type
+	14, // [14:29] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_vimonade_proto_init() }
+func file_vimonade_proto_init() {
+	if File_vimonade_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_vimonade_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyOrigin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PasteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PasteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PasteChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Payload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snippet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveSnippetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveSnippetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSnippetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSnippetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSnippetsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSnippetsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSnippetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSnippetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClipboardEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendFileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendFileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vimonade_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_vimonade_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*CopyChunk_Info)(nil),
+		(*CopyChunk_ChunkData)(nil),
+	}
+	file_vimonade_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*PasteChunk_Info)(nil),
+		(*PasteChunk_ChunkData)(nil),
+	}
+	file_vimonade_proto_msgTypes[26].OneofWrappers = []interface{}{
+		(*SendFileRequest_Info)(nil),
+		(*SendFileRequest_ChunkData)(nil),
+	}
+	file_vimonade_proto_msgTypes[32].OneofWrappers = []interface{}{
+		(*FetchChunk_Info)(nil),
+		(*FetchChunk_ChunkData)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_vimonade_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   33,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vimonade_proto_goTypes,
+		DependencyIndexes: file_vimonade_proto_depIdxs,
+		MessageInfos:      file_vimonade_proto_msgTypes,
+	}.Build()
+	File_vimonade_proto = out.File
+	file_vimonade_proto_rawDesc = nil
+	file_vimonade_proto_goTypes = nil
+	file_vimonade_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// VimonadeServiceClient is the client API for VimonadeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type VimonadeServiceClient interface {
+	Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (*CopyResponse, error)
+	Paste(ctx context.Context, in *PasteRequest, opts ...grpc.CallOption) (*PasteResponse, error)
+	// CopyStream and PasteStream chunk content past the gRPC message size limit
+	CopyStream(ctx context.Context, opts ...grpc.CallOption) (VimonadeService_CopyStreamClient, error)
+	PasteStream(ctx context.Context, in *PasteRequest, opts ...grpc.CallOption) (VimonadeService_PasteStreamClient, error)
+	Send(ctx context.Context, opts ...grpc.CallOption) (VimonadeService_SendClient, error)
+	// UploadStatus answers how much of a resumable Send the server already has
+	UploadStatus(ctx context.Context, in *UploadStatusRequest, opts ...grpc.CallOption) (*UploadStatusResponse, error)
+	// Fetch streams a stored file: its info first, then its content
+	Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (VimonadeService_FetchClient, error)
+	ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryResponse, error)
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	DeleteHistory(ctx context.Context, in *DeleteHistoryRequest, opts ...grpc.CallOption) (*DeleteHistoryResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (VimonadeService_WatchClient, error)
+	SaveSnippet(ctx context.Context, in *SaveSnippetRequest, opts ...grpc.CallOption) (*SaveSnippetResponse, error)
+	GetSnippet(ctx context.Context, in *GetSnippetRequest, opts ...grpc.CallOption) (*GetSnippetResponse, error)
+	ListSnippets(ctx context.Context, in *ListSnippetsRequest, opts ...grpc.CallOption) (*ListSnippetsResponse, error)
+	DeleteSnippet(ctx context.Context, in *DeleteSnippetRequest, opts ...grpc.CallOption) (*DeleteSnippetResponse, error)
+}
+
+type vimonadeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVimonadeServiceClient(cc grpc.ClientConnInterface) VimonadeServiceClient {
+	return &vimonadeServiceClient{cc}
+}
+
+func (c *vimonadeServiceClient) Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (*CopyResponse, error) {
+	out := new(CopyResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/Copy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vimonadeServiceClient) Paste(ctx context.Context, in *PasteRequest, opts ...grpc.CallOption) (*PasteResponse, error) {
+	out := new(PasteResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/Paste", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vimonadeServiceClient) CopyStream(ctx context.Context, opts ...grpc.CallOption) (VimonadeService_CopyStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_VimonadeService_serviceDesc.Streams[0], "/vimonade.VimonadeService/CopyStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vimonadeServiceCopyStreamClient{stream}
+	return x, nil
+}
+
+type VimonadeService_CopyStreamClient interface {
+	Send(*CopyChunk) error
+	CloseAndRecv() (*CopyResponse, error)
+	grpc.ClientStream
+}
+
+type vimonadeServiceCopyStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *vimonadeServiceCopyStreamClient) Send(m *CopyChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vimonadeServiceCopyStreamClient) CloseAndRecv() (*CopyResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CopyResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vimonadeServiceClient) PasteStream(ctx context.Context, in *PasteRequest, opts ...grpc.CallOption) (VimonadeService_PasteStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_VimonadeService_serviceDesc.Streams[1], "/vimonade.VimonadeService/PasteStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vimonadeServicePasteStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VimonadeService_PasteStreamClient interface {
+	Recv() (*PasteChunk, error)
+	grpc.ClientStream
+}
+
+type vimonadeServicePasteStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *vimonadeServicePasteStreamClient) Recv() (*PasteChunk, error) {
+	m := new(PasteChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vimonadeServiceClient) Send(ctx context.Context, opts ...grpc.CallOption) (VimonadeService_SendClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_VimonadeService_serviceDesc.Streams[2], "/vimonade.VimonadeService/Send", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vimonadeServiceSendClient{stream}
+	return x, nil
+}
+
+type VimonadeService_SendClient interface {
+	Send(*SendFileRequest) error
+	CloseAndRecv() (*SendFileResponse, error)
+	grpc.ClientStream
+}
+
+type vimonadeServiceSendClient struct {
+	grpc.ClientStream
+}
+
+func (x *vimonadeServiceSendClient) Send(m *SendFileRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vimonadeServiceSendClient) CloseAndRecv() (*SendFileResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(SendFileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vimonadeServiceClient) UploadStatus(ctx context.Context, in *UploadStatusRequest, opts ...grpc.CallOption) (*UploadStatusResponse, error) {
+	out := new(UploadStatusResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/UploadStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vimonadeServiceClient) Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (VimonadeService_FetchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_VimonadeService_serviceDesc.Streams[3], "/vimonade.VimonadeService/Fetch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vimonadeServiceFetchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VimonadeService_FetchClient interface {
+	Recv() (*FetchChunk, error)
+	grpc.ClientStream
+}
+
+type vimonadeServiceFetchClient struct {
+	grpc.ClientStream
+}
+
+func (x *vimonadeServiceFetchClient) Recv() (*FetchChunk, error) {
+	m := new(FetchChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vimonadeServiceClient) ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryResponse, error) {
+	out := new(ListHistoryResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/ListHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vimonadeServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/GetHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vimonadeServiceClient) DeleteHistory(ctx context.Context, in *DeleteHistoryRequest, opts ...grpc.CallOption) (*DeleteHistoryResponse, error) {
+	out := new(DeleteHistoryResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/DeleteHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vimonadeServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (VimonadeService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_VimonadeService_serviceDesc.Streams[4], "/vimonade.VimonadeService/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vimonadeServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VimonadeService_WatchClient interface {
+	Recv() (*ClipboardEvent, error)
+	grpc.ClientStream
+}
+
+type vimonadeServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *vimonadeServiceWatchClient) Recv() (*ClipboardEvent, error) {
+	m := new(ClipboardEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vimonadeServiceClient) SaveSnippet(ctx context.Context, in *SaveSnippetRequest, opts ...grpc.CallOption) (*SaveSnippetResponse, error) {
+	out := new(SaveSnippetResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/SaveSnippet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vimonadeServiceClient) GetSnippet(ctx context.Context, in *GetSnippetRequest, opts ...grpc.CallOption) (*GetSnippetResponse, error) {
+	out := new(GetSnippetResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/GetSnippet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vimonadeServiceClient) ListSnippets(ctx context.Context, in *ListSnippetsRequest, opts ...grpc.CallOption) (*ListSnippetsResponse, error) {
+	out := new(ListSnippetsResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/ListSnippets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vimonadeServiceClient) DeleteSnippet(ctx context.Context, in *DeleteSnippetRequest, opts ...grpc.CallOption) (*DeleteSnippetResponse, error) {
+	out := new(DeleteSnippetResponse)
+	err := c.cc.Invoke(ctx, "/vimonade.VimonadeService/DeleteSnippet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VimonadeServiceServer is the server API for VimonadeService service.
 type VimonadeServiceServer interface {
 	Copy(context.Context, *CopyRequest) (*CopyResponse, error)
 	Paste(context.Context, *PasteRequest) (*PasteResponse, error)
+	// CopyStream and PasteStream chunk content past the gRPC message size limit
+	CopyStream(VimonadeService_CopyStreamServer) error
+	PasteStream(*PasteRequest, VimonadeService_PasteStreamServer) error
 	Send(VimonadeService_SendServer) error
+	// UploadStatus answers how much of a resumable Send the server already has
+	UploadStatus(context.Context, *UploadStatusRequest) (*UploadStatusResponse, error)
+	// Fetch streams a stored file: its info first, then its content
+	Fetch(*FetchRequest, VimonadeService_FetchServer) error
+	ListHistory(context.Context, *ListHistoryRequest) (*ListHistoryResponse, error)
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	DeleteHistory(context.Context, *DeleteHistoryRequest) (*DeleteHistoryResponse, error)
+	Watch(*WatchRequest, VimonadeService_WatchServer) error
+	SaveSnippet(context.Context, *SaveSnippetRequest) (*SaveSnippetResponse, error)
+	GetSnippet(context.Context, *GetSnippetRequest) (*GetSnippetResponse, error)
+	ListSnippets(context.Context, *ListSnippetsRequest) (*ListSnippetsResponse, error)
+	DeleteSnippet(context.Context, *DeleteSnippetRequest) (*DeleteSnippetResponse, error)
 }
 
 // UnimplementedVimonadeServiceServer can be embedded to have forward compatible implementations.
 type UnimplementedVimonadeServiceServer struct {
 }
 
-func (*UnimplementedVimonadeServiceServer) Copy(ctx context.Context, req *CopyRequest) (*CopyResponse, error) {
+func (*UnimplementedVimonadeServiceServer) Copy(context.Context, *CopyRequest) (*CopyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Copy not implemented")
 }
-func (*UnimplementedVimonadeServiceServer) Paste(ctx context.Context, req *PasteRequest) (*PasteResponse, error) {
+func (*UnimplementedVimonadeServiceServer) Paste(context.Context, *PasteRequest) (*PasteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Paste not implemented")
 }
-func (*UnimplementedVimonadeServiceServer) Send(srv VimonadeService_SendServer) error {
+func (*UnimplementedVimonadeServiceServer) CopyStream(VimonadeService_CopyStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method CopyStream not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) PasteStream(*PasteRequest, VimonadeService_PasteStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method PasteStream not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) Send(VimonadeService_SendServer) error {
 	return status.Errorf(codes.Unimplemented, "method Send not implemented")
 }
+func (*UnimplementedVimonadeServiceServer) UploadStatus(context.Context, *UploadStatusRequest) (*UploadStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadStatus not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) Fetch(*FetchRequest, VimonadeService_FetchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Fetch not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) ListHistory(context.Context, *ListHistoryRequest) (*ListHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHistory not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) DeleteHistory(context.Context, *DeleteHistoryRequest) (*DeleteHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteHistory not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) Watch(*WatchRequest, VimonadeService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) SaveSnippet(context.Context, *SaveSnippetRequest) (*SaveSnippetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveSnippet not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) GetSnippet(context.Context, *GetSnippetRequest) (*GetSnippetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnippet not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) ListSnippets(context.Context, *ListSnippetsRequest) (*ListSnippetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnippets not implemented")
+}
+func (*UnimplementedVimonadeServiceServer) DeleteSnippet(context.Context, *DeleteSnippetRequest) (*DeleteSnippetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSnippet not implemented")
+}
 
 func RegisterVimonadeServiceServer(s *grpc.Server, srv VimonadeServiceServer) {
 	s.RegisterService(&_VimonadeService_serviceDesc, srv)
@@ -514,6 +3426,53 @@ func _VimonadeService_Paste_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VimonadeService_CopyStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VimonadeServiceServer).CopyStream(&vimonadeServiceCopyStreamServer{stream})
+}
+
+type VimonadeService_CopyStreamServer interface {
+	SendAndClose(*CopyResponse) error
+	Recv() (*CopyChunk, error)
+	grpc.ServerStream
+}
+
+type vimonadeServiceCopyStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *vimonadeServiceCopyStreamServer) SendAndClose(m *CopyResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vimonadeServiceCopyStreamServer) Recv() (*CopyChunk, error) {
+	m := new(CopyChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _VimonadeService_PasteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PasteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VimonadeServiceServer).PasteStream(m, &vimonadeServicePasteStreamServer{stream})
+}
+
+type VimonadeService_PasteStreamServer interface {
+	Send(*PasteChunk) error
+	grpc.ServerStream
+}
+
+type vimonadeServicePasteStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *vimonadeServicePasteStreamServer) Send(m *PasteChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _VimonadeService_Send_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(VimonadeServiceServer).Send(&vimonadeServiceSendServer{stream})
 }
@@ -540,6 +3499,192 @@ func (x *vimonadeServiceSendServer) Recv() (*SendFileRequest, error) {
 	return m, nil
 }
 
+func _VimonadeService_UploadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VimonadeServiceServer).UploadStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vimonade.VimonadeService/UploadStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VimonadeServiceServer).UploadStatus(ctx, req.(*UploadStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VimonadeService_Fetch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VimonadeServiceServer).Fetch(m, &vimonadeServiceFetchServer{stream})
+}
+
+type VimonadeService_FetchServer interface {
+	Send(*FetchChunk) error
+	grpc.ServerStream
+}
+
+type vimonadeServiceFetchServer struct {
+	grpc.ServerStream
+}
+
+func (x *vimonadeServiceFetchServer) Send(m *FetchChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VimonadeService_ListHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VimonadeServiceServer).ListHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vimonade.VimonadeService/ListHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VimonadeServiceServer).ListHistory(ctx, req.(*ListHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VimonadeService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VimonadeServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vimonade.VimonadeService/GetHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VimonadeServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VimonadeService_DeleteHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VimonadeServiceServer).DeleteHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vimonade.VimonadeService/DeleteHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VimonadeServiceServer).DeleteHistory(ctx, req.(*DeleteHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VimonadeService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VimonadeServiceServer).Watch(m, &vimonadeServiceWatchServer{stream})
+}
+
+type VimonadeService_WatchServer interface {
+	Send(*ClipboardEvent) error
+	grpc.ServerStream
+}
+
+type vimonadeServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *vimonadeServiceWatchServer) Send(m *ClipboardEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VimonadeService_SaveSnippet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveSnippetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VimonadeServiceServer).SaveSnippet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vimonade.VimonadeService/SaveSnippet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VimonadeServiceServer).SaveSnippet(ctx, req.(*SaveSnippetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VimonadeService_GetSnippet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnippetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VimonadeServiceServer).GetSnippet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vimonade.VimonadeService/GetSnippet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VimonadeServiceServer).GetSnippet(ctx, req.(*GetSnippetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VimonadeService_ListSnippets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnippetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VimonadeServiceServer).ListSnippets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vimonade.VimonadeService/ListSnippets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VimonadeServiceServer).ListSnippets(ctx, req.(*ListSnippetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VimonadeService_DeleteSnippet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSnippetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VimonadeServiceServer).DeleteSnippet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vimonade.VimonadeService/DeleteSnippet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VimonadeServiceServer).DeleteSnippet(ctx, req.(*DeleteSnippetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _VimonadeService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "vimonade.VimonadeService",
 	HandlerType: (*VimonadeServiceServer)(nil),
@@ -552,13 +3697,65 @@ var _VimonadeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Paste",
 			Handler:    _VimonadeService_Paste_Handler,
 		},
+		{
+			MethodName: "UploadStatus",
+			Handler:    _VimonadeService_UploadStatus_Handler,
+		},
+		{
+			MethodName: "ListHistory",
+			Handler:    _VimonadeService_ListHistory_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _VimonadeService_GetHistory_Handler,
+		},
+		{
+			MethodName: "DeleteHistory",
+			Handler:    _VimonadeService_DeleteHistory_Handler,
+		},
+		{
+			MethodName: "SaveSnippet",
+			Handler:    _VimonadeService_SaveSnippet_Handler,
+		},
+		{
+			MethodName: "GetSnippet",
+			Handler:    _VimonadeService_GetSnippet_Handler,
+		},
+		{
+			MethodName: "ListSnippets",
+			Handler:    _VimonadeService_ListSnippets_Handler,
+		},
+		{
+			MethodName: "DeleteSnippet",
+			Handler:    _VimonadeService_DeleteSnippet_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CopyStream",
+			Handler:       _VimonadeService_CopyStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PasteStream",
+			Handler:       _VimonadeService_PasteStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Send",
 			Handler:       _VimonadeService_Send_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Fetch",
+			Handler:       _VimonadeService_Fetch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _VimonadeService_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "vimonade.proto",
 }
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+const (
+	// cacheTTL is how long a content is cached without being pasted again
+	cacheTTL = 7 * 24 * time.Hour
+	// maxCacheSize bounds the size of the cache, dropping the least recently pasted contents
+	maxCacheSize = 64 << 20
+)
+
+// pasteCache keeps the content last pasted from each register in files,
+// so that pasting it again only asks the server whether it changed.
+// A nil pasteCache caches nothing.
+type pasteCache struct {
+	dir string
+}
+
+// cacheEntry is the content of a register as pasted by the server
+type cacheEntry struct {
+	Version  string `json:"version"`
+	Regtype  string `json:"regtype"`
+	MimeType string `json:"mime_type,omitempty"`
+	Data     []byte `json:"data"`
+}
+
+// newPasteCache returns a pasteCache in the user cache directory, or nil if there is none
+func newPasteCache() *pasteCache {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil
+	}
+
+	return &pasteCache{dir: filepath.Join(dir, "vimonade", "paste")}
+}
+
+func (cache *pasteCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(cache.dir, hex.EncodeToString(sum[:]))
+}
+
+// load returns the cached content of key, or nil
+func (cache *pasteCache) load(key string) *cacheEntry {
+	if cache == nil {
+		return nil
+	}
+
+	path := cache.path(key)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+
+	if time.Since(info.ModTime()) > cacheTTL {
+		os.Remove(path)
+		return nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil || entry.Version == "" {
+		return nil
+	}
+
+	// the modification time records the last paste of the content
+	now := time.Now()
+	os.Chtimes(path, now, now)
+
+	return &entry
+}
+
+// save replaces the cached content of key
+func (cache *pasteCache) save(key string, entry *cacheEntry) error {
+	if cache == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(cache.dir, 0700); err != nil {
+		return fmt.Errorf("cannot create paste cache: %s", err)
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("cannot encode paste cache: %s", err)
+	}
+
+	if err := ioutil.WriteFile(cache.path(key), b, 0600); err != nil {
+		return fmt.Errorf("cannot write paste cache: %s", err)
+	}
+
+	cache.prune()
+
+	return nil
+}
+
+// prune drops the contents which expired, then the least recently pasted ones
+// until the cache fits in maxCacheSize
+func (cache *pasteCache) prune() {
+	files, err := ioutil.ReadDir(cache.dir)
+	if err != nil {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime().After(files[j].ModTime()) })
+
+	size := int64(0)
+
+	for _, info := range files {
+		if time.Since(info.ModTime()) > cacheTTL || size+info.Size() > maxCacheSize {
+			os.Remove(filepath.Join(cache.dir, info.Name()))
+			continue
+		}
+
+		size += info.Size()
+	}
+}
+
+// remove drops the cached content of key
+func (cache *pasteCache) remove(key string) {
+	if cache == nil {
+		return
+	}
+
+	os.Remove(cache.path(key))
+}
+
+// cacheKey identifies the register pasted by the client
+func (c *client) cacheKey() string {
+	register := c.register
+	if lemon.IsClipboardRegister(register) {
+		register = "+"
+	}
+
+	mimeType := c.mimeType
+	if lemon.IsText(mimeType) {
+		mimeType = ""
+	}
+
+	return fmt.Sprintf("%s:%d %s %s %s %d %s", c.host, c.port, register, c.selection, mimeType, c.index, c.channel)
+}
+
+// cachePaste caches the content of a paste, unless it's sensitive
+func (c *client) cachePaste(key string, res *pb.PasteResponse) {
+	if res.GetSensitive() || res.GetVersion() == "" {
+		c.cache.remove(key)
+		return
+	}
+
+	entry := &cacheEntry{Version: res.GetVersion(), Regtype: res.GetRegtype(), Data: []byte(res.GetValue())}
+
+	if payloads := res.GetPayloads(); len(payloads) > 0 {
+		entry.MimeType = payloads[0].GetMimeType()
+		entry.Data = payloads[0].GetData()
+	}
+
+	if err := c.cache.save(key, entry); err != nil {
+		c.logger.Debug(err.Error())
+	}
+}
+
+// cacheCopy caches copied content when the server pastes it back as is,
+// so that the next paste doesn't transfer it again. Sensitive copies, also those
+// the server found secrets in, aren't cached.
+func (c *client) cacheCopy(req *pb.CopyRequest, res *pb.CopyResponse) {
+	key := c.cacheKey()
+	version := res.GetVersion()
+
+	regtype := req.GetRegtype()
+	if regtype == "" {
+		regtype = lemon.InferRegtype(req.GetValue())
+	}
+
+	entry := &cacheEntry{Version: version, Regtype: regtype, Data: []byte(req.GetValue())}
+
+	if payloads := req.GetPayloads(); len(payloads) > 0 {
+		entry.MimeType = payloads[0].GetMimeType()
+		entry.Data = payloads[0].GetData()
+
+		if req.GetRegtype() == "" {
+			entry.Regtype = lemon.InferRegtype(string(entry.Data))
+		}
+	}
+
+	if c.expires() || res.GetSensitive() || version == "" || lemon.ContentVersion(entry.Regtype, entry.MimeType, entry.Data) != version {
+		c.cache.remove(key)
+		return
+	}
+
+	if err := c.cache.save(key, entry); err != nil {
+		c.logger.Debug(err.Error())
+	}
+}
@@ -3,16 +3,20 @@ package client
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/atotto/clipboard"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/jrc2139/vimonade/api"
 	"github.com/jrc2139/vimonade/lemon"
@@ -20,12 +24,31 @@ import (
 
 const (
 	timeOut = 5 * time.Second
+	// dialTimeOut bounds blocking dials, after which copies are queued
+	dialTimeOut = 2 * time.Second
 )
 
 type client struct {
 	host       string
 	port       int
 	lineEnding string
+	register   string
+	regtype    string
+	selection  string
+	channel    string
+	index      int
+	format     string
+	mimeType   string
+	trim       bool
+	skipBlank  bool
+	sensitive  bool
+	ttl        time.Duration
+	transform  lemon.Pipeline
+	charset    *lemon.Charset
+	conn       *grpc.ClientConn
+	connected  bool
+	cache      *pasteCache
+	queue      *copyQueue
 	logger     *zap.Logger
 	grpcClient pb.VimonadeServiceClient
 }
@@ -35,78 +58,287 @@ func New(c *lemon.CLI, conn *grpc.ClientConn, logger *zap.Logger) *client {
 		host:       c.Host,
 		port:       c.Port,
 		lineEnding: c.LineEnding,
+		register:   c.Register,
+		regtype:    c.Regtype,
+		selection:  c.Selection,
+		channel:    c.Channel,
+		index:      c.Index,
+		format:     c.Format,
+		mimeType:   c.MimeType,
+		trim:       c.Trim,
+		skipBlank:  c.SkipBlank,
+		sensitive:  c.Sensitive,
+		ttl:        c.TTL,
 		logger:     logger,
 		grpcClient: pb.NewVimonadeServiceClient(conn),
+		conn:       conn,
+		connected:  conn != nil,
+		cache:      newPasteCache(),
+		queue:      newCopyQueue(),
 	}
 }
 func Copy(c *lemon.CLI, logger *zap.Logger, opts ...grpc.DialOption) int {
-	isConnected := true
+	regtype, err := lemon.NormalizeRegtype(c.Regtype)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
 
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", c.Host, c.Port), opts...)
+	selection, err := lemon.NormalizeSelection(c.Selection)
 	if err != nil {
-		// don't return err if connection isn't made
-		logger.Debug("failed to dial server: " + err.Error())
-		isConnected = false
+		writeError(c, err)
+		return lemon.FlagParseError
 	}
-	defer conn.Close()
 
-	lc := New(c, conn, logger)
+	transform, err := lemon.ParsePipeline(c.Transform)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
 
-	if err := lc.copyText(c.DataSource, isConnected); err != nil {
+	charset, err := lemon.LookupCharset(c.Charset)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	channel, err := lemon.NormalizeChannel(c.Channel)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	endpoints, err := lemon.ParseEndpoints(c.Host, c.Port)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	c.Regtype = regtype
+	c.Selection = selection
+	c.Channel = channel
+
+	// don't return err if connection isn't made
+	clients := dialAll(c, endpoints, logger, opts...)
+	defer closeAll(clients)
+
+	for _, lc := range clients {
+		lc.transform = transform
+		lc.charset = charset
+	}
+
+	lc := clients[0]
+
+	text, ok, err := lc.prepareText(c.DataSource)
+	if err != nil {
 		logger.Error("failed to Copy: " + err.Error())
 		writeError(c, err)
 
 		return lemon.RPCError
 	}
 
+	if !ok {
+		return lemon.Success
+	}
+
+	if len(clients) > 1 {
+		err = copyAll(c.Err, clients, text)
+	} else {
+		_, err = lc.copyRemote(text)
+	}
+
+	if err != nil {
+		logger.Error("failed to Copy: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+
+	lc.copyLocal(text)
+
 	return lemon.Success
 }
 
-func (c *client) copyText(text string, cnx bool) error {
-	c.logger.Debug("Copying: " + text)
+// prepareText returns the text to copy, and false if it's blank and blank text is skipped
+func (c *client) prepareText(text string) (string, bool, error) {
+	if lemon.IsText(c.mimeType) {
+		var err error
 
-	// not interested in copying blank and newlines
-	switch text {
-	case "":
-		return nil
-	case "\n":
-		return nil
-	default:
-		if cnx {
-			ctx, cancel := context.WithTimeout(context.Background(), timeOut)
-			defer cancel()
-
-			_, err := c.grpcClient.Copy(ctx, &pb.CopyRequest{Value: strings.TrimSpace(text)})
-			if err != nil {
-				c.logger.Debug("error with client copying " + err.Error())
-			}
+		if text, err = c.charset.Decode(text); err != nil {
+			return "", false, err
 		}
+
+		if text, err = c.transform.Apply(text); err != nil {
+			return "", false, err
+		}
+	}
+
+	if c.trim {
+		text = strings.TrimSpace(text)
+	}
+
+	if c.sensitive {
+		c.logger.Debug("Copying: <sensitive>")
+	} else {
+		c.logger.Debug("Copying: " + text)
+	}
+
+	if c.skipBlank && strings.TrimSpace(text) == "" {
+		return "", false, nil
+	}
+
+	return text, true, nil
+}
+
+// copyRemote copies text to the server, or queues the copy if the server can't be reached.
+// It reports whether the server got the copy.
+func (c *client) copyRemote(text string) (bool, error) {
+	if !c.connected {
+		if c.expires() {
+			return false, fmt.Errorf("cannot reach the server to copy an expiring clip")
+		}
+
+		c.queueCopy(c.copyRequest(text))
+
+		return false, nil
+	}
+
+	req := c.copyRequest(text)
+
+	res, err := c.copy(req)
+	if err != nil {
+		c.logger.Debug("error with client copying " + err.Error())
+
+		if !isUnreachable(err) || c.expires() {
+			return false, err
+		}
+
+		c.queueCopy(req)
+
+		return false, nil
+	}
+
+	c.cacheCopy(req, res)
+
+	return true, nil
+}
+
+// copyLocal copies text to the local clipboard
+func (c *client) copyLocal(text string) {
+	// named registers, channels, other selections and formats than text only live on the server.
+	// The local clipboard couldn't expire a copy.
+	if !c.isLocal() || c.expires() {
+		return
 	}
 
 	if err := clipboard.WriteAll(text); err != nil {
 		c.logger.Error("error writing to clipboard: " + err.Error())
 	}
+}
 
-	return nil
+func (c *client) copyRequest(text string) *pb.CopyRequest {
+	req := &pb.CopyRequest{
+		Register:  c.register,
+		Regtype:   c.regtype,
+		Selection: c.selection,
+		Channel:   c.channel,
+		Sensitive: c.sensitive,
+		Ttl:       int64(c.ttl / time.Millisecond),
+		Origin:    newCopyOrigin(),
+	}
+
+	switch {
+	case !lemon.IsText(c.mimeType):
+		req.Payloads = []*pb.Payload{{MimeType: c.mimeType, Data: []byte(text)}}
+	case !utf8.ValidString(text):
+		// proto strings must be valid UTF-8, so send the text as bytes
+		req.Payloads = []*pb.Payload{{MimeType: lemon.TextMimeType, Data: []byte(text)}}
+	default:
+		req.Value = text
+	}
+
+	return req
 }
 
 func Paste(c *lemon.CLI, logger *zap.Logger, opts ...grpc.DialOption) int {
-	isConnected := true
+	selection, err := lemon.NormalizeSelection(c.Selection)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
 
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", c.Host, c.Port), opts...)
+	transform, err := lemon.ParsePipeline(c.Transform)
 	if err != nil {
-		// don't return err if connection isn't made
-		logger.Debug("failed to dial server: " + err.Error())
-		isConnected = false
+		writeError(c, err)
+		return lemon.FlagParseError
 	}
-	defer conn.Close()
 
-	lc := New(c, conn, logger)
+	charset, err := lemon.LookupCharset(c.Charset)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	channel, err := lemon.NormalizeChannel(c.Channel)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	endpoints, err := lemon.ParseEndpoints(c.Host, c.Port)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
 
-	var text string
+	if c.PasteFrom != pasteFromFirst && c.PasteFrom != pasteFromNewest {
+		writeError(c, fmt.Errorf("unknown paste source: %s", c.PasteFrom))
+		return lemon.FlagParseError
+	}
 
-	text = lc.pasteText(isConnected)
-	if _, err := c.Out.Write([]byte(text)); err != nil {
+	c.Selection = selection
+	c.Channel = channel
+
+	// don't return err if connection isn't made
+	clients := dialAll(c, endpoints, logger, opts...)
+	defer closeAll(clients)
+
+	for _, lc := range clients {
+		lc.transform = transform
+		lc.charset = charset
+	}
+
+	lc := clients[0]
+
+	var text, regtype string
+
+	if len(clients) > 1 {
+		text, regtype, err = pasteAll(c.Err, clients, c.PasteFrom == pasteFromNewest)
+	} else {
+		text, regtype, err = lc.pasteText()
+	}
+
+	if err != nil {
+		logger.Debug("failed to Paste: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+
+	if lemon.IsText(c.MimeType) {
+		if text, err = lc.transform.Apply(text); err != nil {
+			writeError(c, err)
+			return lemon.RPCError
+		}
+	}
+
+	out, err := lc.formatPaste(text, regtype)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	if _, err := c.Out.Write(out); err != nil {
 		logger.Error("Failed to output Paste to stdin: " + err.Error())
 		writeError(c, err)
 
@@ -116,28 +348,174 @@ func Paste(c *lemon.CLI, logger *zap.Logger, opts ...grpc.DialOption) int {
 	return lemon.Success
 }
 
-func (c *client) pasteText(cnx bool) string {
+// pasted is the clipboard content pasted from a server
+type pasted struct {
+	text    string
+	regtype string
+	// copiedAt is when the content was copied in milliseconds, 0 if unknown
+	copiedAt int64
+}
+
+// pasteText returns the clipboard text and its register type.
+// Errors are only returned when the server could be reached.
+func (c *client) pasteText() (string, string, error) {
+	if c.connected {
+		p, err := c.pasteRemote()
+		if err == nil {
+			return p.text, p.regtype, nil
+		}
+
+		if !isUnreachable(err) {
+			return "", "", err
+		}
+	}
+
+	text, regtype := c.pasteLocal()
+
+	return text, regtype, nil
+}
+
+// pasteRemote returns the clipboard content of the server
+func (c *client) pasteRemote() (*pasted, error) {
 	c.logger.Debug("Receiving")
 
+	key := c.cacheKey()
+	cached := c.cache.load(key)
+
+	req := &pb.PasteRequest{
+		Register:  c.register,
+		Index:     uint32(c.index),
+		MimeType:  c.mimeType,
+		Selection: c.selection,
+		Channel:   c.channel,
+	}
+
+	if cached != nil {
+		req.IfNoneMatch = cached.Version
+	}
+
+	res, err := c.paste(req)
+	if err != nil {
+		c.logger.Debug("error with client pasting " + err.Error())
+		return nil, err
+	}
+
+	p := &pasted{text: res.GetValue(), regtype: res.GetRegtype(), copiedAt: res.GetCopiedAt()}
+
+	if res.GetUnchanged() && cached != nil {
+		c.logger.Debug("paste unchanged, using the cache: " + res.GetVersion())
+		p.text = string(cached.Data)
+
+		// the content became sensitive since it was cached, e.g. it holds a secret
+		if res.GetSensitive() {
+			c.cache.remove(key)
+		}
+
+		return p, nil
+	}
+
+	c.cachePaste(key, res)
+
+	if payloads := res.GetPayloads(); len(payloads) > 0 {
+		p.text = string(payloads[0].GetData())
+	}
+
+	return p, nil
+}
+
+// pasteLocal returns the local clipboard text when no server can be reached
+func (c *client) pasteLocal() (string, string) {
+	// named registers, channels, history, other selections and formats than text only live on the server
+	if !c.isLocal() || c.index > 0 {
+		return "", lemon.Charwise
+	}
+
 	text, err := clipboard.ReadAll()
 	if err != nil {
 		c.logger.Error("error reading from clipboard: " + err.Error())
 	}
 
-	if cnx {
-		ctx, cancel := context.WithTimeout(context.Background(), timeOut)
-		defer cancel()
+	return text, lemon.InferRegtype(text)
+}
 
-		if _, err := c.grpcClient.Paste(ctx, &pb.PasteRequest{Value: text}); err != nil {
-			c.logger.Debug("error with client pasting " + err.Error())
-		}
+// flush sends the copies queued while the server couldn't be reached
+func (c *client) flush(collapse bool) {
+	sent, err := c.flushQueue(collapse)
+	if err != nil {
+		c.logger.Error("error flushing queued copies: " + err.Error())
+	}
+
+	if sent > 0 {
+		c.logger.Debug(fmt.Sprintf("flushed %d queued copies", sent))
+	}
+}
+
+// address returns the first server of --host, since only copy and paste fan out to several
+func address(c *lemon.CLI) string {
+	endpoints, err := lemon.ParseEndpoints(c.Host, c.Port)
+	if err != nil {
+		return fmt.Sprintf("%s:%d", c.Host, c.Port)
 	}
 
-	return lemon.ConvertLineEnding(text, c.lineEnding)
+	return endpoints[0].String()
+}
+
+// dial connects to target, blocking for dialTimeOut at most
+func dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeOut)
+	defer cancel()
+
+	return grpc.DialContext(ctx, target, opts...)
+}
+
+// expires reports whether the copied content is removed from the server clipboard after a while
+func (c *client) expires() bool {
+	return c.sensitive || c.ttl > 0
+}
+
+// isLocal reports whether the copied or pasted content can also be held by the local clipboard
+func (c *client) isLocal() bool {
+	return lemon.IsClipboardRegister(c.register) && c.selection == lemon.SelectionClipboard && c.channel == "" && lemon.IsText(c.mimeType)
+}
+
+// isUnreachable reports whether a gRPC error means the server couldn't be reached
+func isUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatPaste renders pasted text either as is in the output charset, or as the [lines, regtype]
+// list returned by a g:clipboard paste function, which json keeps in UTF-8
+func (c *client) formatPaste(text, regtype string) ([]byte, error) {
+	if !lemon.IsText(c.mimeType) {
+		return []byte(text), nil
+	}
+
+	switch c.format {
+	case "text", "":
+		encoded, err := c.charset.Encode(lemon.ConvertLineEnding(text, c.lineEnding))
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(encoded), nil
+	case "json":
+		if regtype == "" {
+			regtype = lemon.InferRegtype(text)
+		}
+
+		return json.Marshal([]interface{}{lemon.SplitLines(text, regtype), regtype})
+	default:
+		return nil, fmt.Errorf("unknown paste format: %s", c.format)
+	}
 }
 
 func Send(c *lemon.CLI, logger *zap.Logger, opts ...grpc.DialOption) int {
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", c.Host, c.Port), opts...)
+	conn, err := grpc.Dial(address(c), opts...)
 	if err != nil {
 		logger.Fatal("failed to dial server: " + err.Error())
 		return lemon.RPCError
@@ -169,25 +547,60 @@ func (c *client) send(path string) error {
 	}
 	defer file.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
-	defer cancel()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	// an interrupted upload of the same file is resumed where the server stopped receiving it
+	id := uploadID(path, info)
 
-	stream, err := c.grpcClient.Send(ctx)
+	offset, err := c.uploadOffset(id)
 	if err != nil {
 		return err
 	}
 
+	if offset > info.Size() {
+		offset = 0
+	}
+
+	// the server checks the file against the digest before storing it
+	digest, err := fileDigest(file)
+	if err != nil {
+		return err
+	}
+
+	if offset > 0 {
+		c.logger.Info(fmt.Sprintf("resuming upload of %s at %d of %d bytes", path, offset, info.Size()))
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	// a large file takes as long as it takes, only a stalled upload times out
+	idle := newIdleTimeout(timeOut)
+	defer idle.stop()
+
+	stream, err := c.grpcClient.Send(idle.Context())
+	if err != nil {
+		return idle.err(err)
+	}
+
 	req := &pb.SendFileRequest{
 		Data: &pb.SendFileRequest_Info{
 			Info: &pb.FileInfo{
 				Name:     filepath.Base(path),
 				FileType: filepath.Ext(path),
+				UploadId: id,
+				Offset:   uint64(offset),
+				Sha256:   digest,
 			},
 		},
 	}
 
 	if err := stream.Send(req); err != nil {
-		return err
+		return idle.err(err)
 	}
 
 	reader := bufio.NewReader(file)
@@ -209,18 +622,27 @@ func (c *client) send(path string) error {
 			},
 		}
 
-		err = stream.Send(req)
-		if err != nil {
-			return err
+		if err := stream.Send(req); err != nil {
+			return idle.err(err)
 		}
+
+		idle.touch()
 	}
 
 	res, err := stream.CloseAndRecv()
 	if err != nil {
-		return err
+		return idle.err(err)
+	}
+
+	switch res.GetSha256() {
+	case digest:
+	case "":
+		c.logger.Warn("the server returned no digest, cannot verify " + path)
+	default:
+		return fmt.Errorf("integrity check failed: %s has sha256 %s, the server stored %s", path, digest, res.GetSha256())
 	}
 
-	c.logger.Debug(fmt.Sprintf("image sent with id: %s, size: %d", res.GetName(), res.GetSize()))
+	c.logger.Debug(fmt.Sprintf("image sent with id: %s, size: %d, sha256: %s", res.GetName(), res.GetSize(), res.GetSha256()))
 
 	return nil
 }
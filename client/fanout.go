@@ -0,0 +1,189 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// errUnreachable reports a server which couldn't be dialed
+var errUnreachable = status.Error(codes.Unavailable, "cannot reach the server")
+
+// sources of a paste from several servers
+const (
+	pasteFromFirst  = "first"
+	pasteFromNewest = "newest"
+)
+
+// dialAll connects to every server concurrently, flushing the copies queued for them.
+// The client of a server which can't be reached isn't connected.
+func dialAll(c *lemon.CLI, endpoints []lemon.Endpoint, logger *zap.Logger, opts ...grpc.DialOption) []*client {
+	clients := make([]*client, len(endpoints))
+
+	var wg sync.WaitGroup
+
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+
+		go func(i int, endpoint lemon.Endpoint) {
+			defer wg.Done()
+
+			conn, err := dial(endpoint.String(), opts...)
+			if err != nil {
+				logger.Debug("failed to dial server " + endpoint.String() + ": " + err.Error())
+				conn = nil
+			}
+
+			lc := New(c, conn, logger)
+			lc.host, lc.port = endpoint.Host, endpoint.Port
+
+			if lc.connected {
+				lc.flush(c.CollapseQueue)
+			}
+
+			clients[i] = lc
+		}(i, endpoint)
+	}
+
+	wg.Wait()
+
+	return clients
+}
+
+// closeAll closes the connections of clients
+func closeAll(clients []*client) {
+	for _, lc := range clients {
+		if lc.conn != nil {
+			lc.conn.Close()
+		}
+	}
+}
+
+// copyAll copies text to every server concurrently, and reports the result of each one to out.
+// It only fails when no server got nor queued the copy.
+func copyAll(out io.Writer, clients []*client, text string) error {
+	results := make([]string, len(clients))
+	errs := make([]error, len(clients))
+
+	var wg sync.WaitGroup
+
+	for i, lc := range clients {
+		wg.Add(1)
+
+		go func(i int, lc *client) {
+			defer wg.Done()
+
+			reached, err := lc.copyRemote(text)
+
+			switch {
+			case err != nil:
+				results[i], errs[i] = err.Error(), err
+			case reached:
+				results[i] = "copied"
+			default:
+				results[i] = "queued, cannot reach the server"
+			}
+		}(i, lc)
+	}
+
+	wg.Wait()
+
+	failed := 0
+
+	for i, lc := range clients {
+		fmt.Fprintf(out, "%s: %s\n", lc.endpoint(), results[i])
+
+		if errs[i] != nil {
+			failed++
+		}
+	}
+
+	if failed == len(clients) {
+		return fmt.Errorf("copy failed on every server")
+	}
+
+	return nil
+}
+
+// pasteAll pastes from the first server which can be reached, or from the server
+// with the newest clipboard content, reporting the servers which failed to out.
+// It falls back to the local clipboard when no server can be reached.
+// The copy times come from the clocks of the servers, which newest assumes are in sync.
+func pasteAll(out io.Writer, clients []*client, newest bool) (string, string, error) {
+	results := make([]*pasted, len(clients))
+	errs := make([]error, len(clients))
+
+	if newest {
+		var wg sync.WaitGroup
+
+		for i, lc := range clients {
+			if !lc.connected {
+				errs[i] = errUnreachable
+				continue
+			}
+
+			wg.Add(1)
+
+			go func(i int, lc *client) {
+				defer wg.Done()
+
+				results[i], errs[i] = lc.pasteRemote()
+			}(i, lc)
+		}
+
+		wg.Wait()
+	} else {
+		for i, lc := range clients {
+			if !lc.connected {
+				errs[i] = errUnreachable
+				continue
+			}
+
+			if results[i], errs[i] = lc.pasteRemote(); errs[i] == nil {
+				break
+			}
+		}
+	}
+
+	var (
+		best    *pasted
+		lastErr error
+	)
+
+	for i, lc := range clients {
+		switch {
+		case errs[i] != nil:
+			fmt.Fprintf(out, "%s: %v\n", lc.endpoint(), errs[i])
+
+			if !isUnreachable(errs[i]) {
+				lastErr = errs[i]
+			}
+		case results[i] != nil && (best == nil || results[i].copiedAt > best.copiedAt):
+			best = results[i]
+		}
+	}
+
+	if best != nil {
+		return best.text, best.regtype, nil
+	}
+
+	if lastErr != nil {
+		return "", "", lastErr
+	}
+
+	text, regtype := clients[0].pasteLocal()
+
+	return text, regtype, nil
+}
+
+// endpoint returns the address of the server of the client
+func (c *client) endpoint() string {
+	return lemon.Endpoint{Host: c.host, Port: c.port}.String()
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+func Get(c *lemon.CLI, logger *zap.Logger, opts ...grpc.DialOption) int {
+	name, dest, err := parseGetArgs(c.Args)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	conn, err := grpc.Dial(address(c), opts...)
+	if err != nil {
+		logger.Error("failed to dial server: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+	defer conn.Close()
+
+	lc := New(c, conn, logger)
+
+	if err := lc.fetch(name, dest, c.Force); err != nil {
+		logger.Debug("failed to get " + name + ": " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+
+	return lemon.Success
+}
+
+// parseGetArgs validates `get NAME [DEST]`
+func parseGetArgs(args []string) (string, string, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return "", "", fmt.Errorf("get takes a file name and an optional destination")
+	}
+
+	if len(args) == 1 {
+		return args[0], "", nil
+	}
+
+	return args[0], args[1], nil
+}
+
+// fetchDestination returns the path a fetched file is written to:
+// dest, or name in dest if it's a directory, or in the working directory if it's empty
+func fetchDestination(name, dest string) string {
+	name = filepath.Base(name)
+
+	if dest == "" {
+		return name
+	}
+
+	if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		return filepath.Join(dest, name)
+	}
+
+	return dest
+}
+
+// fetch downloads a file of the server store to dest. It's written to a temporary file
+// next to dest, which becomes dest once its size and digest are checked.
+// An existing dest is only replaced with force.
+func (c *client) fetch(name, dest string, force bool) error {
+	dest = fetchDestination(name, dest)
+
+	if _, err := os.Lstat(dest); err == nil && !force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", dest)
+	}
+
+	// a large file takes as long as it takes, only a stalled download times out
+	idle := newIdleTimeout(timeOut)
+	defer idle.stop()
+
+	stream, err := c.grpcClient.Fetch(idle.Context(), &pb.FetchRequest{Name: name})
+	if err != nil {
+		return idle.err(err)
+	}
+
+	chunk, err := stream.Recv()
+	if err != nil {
+		return idle.err(err)
+	}
+
+	info := chunk.GetInfo()
+	if info == nil {
+		return fmt.Errorf("the server sent no file info for %s", name)
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(dest), ".vimonade-*.part")
+	if err != nil {
+		return err
+	}
+
+	tempPath := file.Name()
+	// the temporary file is removed, unless it was renamed to dest
+	defer os.Remove(tempPath)
+	defer file.Close()
+
+	hash := sha256.New()
+	writer := io.MultiWriter(file, hash)
+	size := uint64(0)
+
+	for {
+		idle.touch()
+
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return idle.err(err)
+		}
+
+		n, err := writer.Write(chunk.GetChunkData())
+		size += uint64(n)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	if size != info.GetSize() || digest != info.GetSha256() {
+		return fmt.Errorf("integrity check failed: %s has %d bytes with sha256 %s, the server sent %d bytes with sha256 %s",
+			name, info.GetSize(), info.GetSha256(), size, digest)
+	}
+
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	// temporary files are only readable by their owner
+	if err := os.Chmod(tempPath, 0644); err != nil {
+		return err
+	}
+
+	if force {
+		if err := os.Rename(tempPath, dest); err != nil {
+			return err
+		}
+	} else if err := os.Link(tempPath, dest); err != nil {
+		// unlike a rename, a link never replaces a file created during the download
+		if os.IsExist(err) {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", dest)
+		}
+
+		return err
+	}
+
+	c.logger.Debug(fmt.Sprintf("fetched %s to %s with size %d, sha256: %s", name, dest, size, digest))
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	pb "github.com/jrc2139/vimonade/api"
+)
+
+// fetchServer serves data as any fetched file
+type fetchServer struct {
+	pb.VimonadeServiceClient
+
+	data []byte
+}
+
+func (s *fetchServer) Fetch(ctx context.Context, req *pb.FetchRequest, opts ...grpc.CallOption) (pb.VimonadeService_FetchClient, error) {
+	digest := sha256.Sum256(s.data)
+
+	return &fetchStream{chunks: []*pb.FetchChunk{
+		{Data: &pb.FetchChunk_Info{Info: &pb.FileInfo{Name: req.GetName(), Size: uint64(len(s.data)), Sha256: hex.EncodeToString(digest[:])}}},
+		{Data: &pb.FetchChunk_ChunkData{ChunkData: s.data}},
+	}}, nil
+}
+
+type fetchStream struct {
+	grpc.ClientStream
+
+	chunks []*pb.FetchChunk
+}
+
+func (s *fetchStream) Recv() (*pb.FetchChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+
+	return chunk, nil
+}
+
+func TestFetch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vimonade")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lc := &client{logger: zap.NewNop(), grpcClient: &fetchServer{data: []byte("fetched")}}
+	dest := filepath.Join(dir, "report.pdf")
+
+	if err := lc.fetch("report.pdf", dir, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if b, _ := ioutil.ReadFile(dest); string(b) != "fetched" {
+		t.Errorf("Expected: %q, got %q", "fetched", b)
+	}
+
+	if err := ioutil.WriteFile(dest, []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// an existing file is only overwritten with --force
+	if err := lc.fetch("report.pdf", dest, false); err == nil {
+		t.Error("Expected an error for an existing destination")
+	}
+
+	if b, _ := ioutil.ReadFile(dest); string(b) != "mine" {
+		t.Errorf("Expected the destination to be kept, got %q", b)
+	}
+
+	if err := lc.fetch("report.pdf", dest, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if b, _ := ioutil.ReadFile(dest); string(b) != "fetched" {
+		t.Errorf("Expected: %q, got %q", "fetched", b)
+	}
+
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("Expected the temporary files to be removed, got %d files", len(files))
+	}
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+func History(c *lemon.CLI, logger *zap.Logger, opts ...grpc.DialOption) int {
+	action, index, err := parseHistoryArgs(c.Args)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	conn, err := grpc.Dial(address(c), opts...)
+	if err != nil {
+		logger.Error("failed to dial server: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+	defer conn.Close()
+
+	lc := New(c, conn, logger)
+
+	if err := lc.history(c.Out, action, index); err != nil {
+		logger.Debug("failed to " + action + " history: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+
+	return lemon.Success
+}
+
+// parseHistoryArgs validates `history [list|get N|rm N|clear]`
+func parseHistoryArgs(args []string) (string, int, error) {
+	if len(args) == 0 {
+		return "list", 0, nil
+	}
+
+	switch args[0] {
+	case "list", "ls", "clear":
+		if len(args) != 1 {
+			return "", 0, fmt.Errorf("history %s takes no argument", args[0])
+		}
+
+		return args[0], 0, nil
+	case "get", "rm":
+		if len(args) != 2 {
+			return "", 0, fmt.Errorf("history %s takes an index", args[0])
+		}
+
+		index, err := strconv.Atoi(args[1])
+		if err != nil || index < 1 {
+			return "", 0, fmt.Errorf("invalid history index: %s", args[1])
+		}
+
+		return args[0], index, nil
+	default:
+		return "", 0, fmt.Errorf("unknown history command: %s", args[0])
+	}
+}
+
+func (c *client) history(out io.Writer, action string, index int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	defer cancel()
+
+	switch action {
+	case "list", "ls":
+		res, err := c.grpcClient.ListHistory(ctx, &pb.ListHistoryRequest{})
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range res.GetEntries() {
+			fmt.Fprintf(out, "%3d  %s  %8dB  %-2s %-20s %s\n",
+				entry.GetIndex(),
+				time.Unix(entry.GetCreatedAt(), 0).Format("2006-01-02 15:04:05"),
+				entry.GetSize(),
+				entry.GetRegister(),
+				originName(entry.GetOrigin()),
+				entry.GetPreview())
+		}
+	case "get":
+		res, err := c.grpcClient.GetHistory(ctx, &pb.GetHistoryRequest{Index: uint32(index)})
+		if err != nil {
+			return err
+		}
+
+		value := res.GetEntry().GetValue()
+		if data := res.GetEntry().GetData(); data != nil {
+			value = string(data)
+		}
+
+		_, err = io.WriteString(out, lemon.ConvertLineEnding(value, c.lineEnding))
+
+		return err
+	case "rm":
+		_, err := c.grpcClient.DeleteHistory(ctx, &pb.DeleteHistoryRequest{Index: uint32(index)})
+		return err
+	case "clear":
+		_, err := c.grpcClient.DeleteHistory(ctx, &pb.DeleteHistoryRequest{All: true})
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package client
+
+import "os"
+
+// lockFile does nothing where files can't be locked: only the copies of a process are serialized
+func lockFile(file *os.File) error {
+	return nil
+}
+
+// unlockFile does nothing where files can't be locked
+func unlockFile(file *os.File) error {
+	return nil
+}
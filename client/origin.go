@@ -0,0 +1,49 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"os/user"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// newCopyOrigin describes this client, with a new request ID identifying the copy
+func newCopyOrigin() *pb.CopyOrigin {
+	origin := &pb.CopyOrigin{
+		User:      os.Getenv("USER"),
+		Version:   lemon.Version,
+		RequestId: newRequestID(),
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		origin.Hostname = hostname
+	}
+
+	if u, err := user.Current(); err == nil {
+		origin.User = u.Username
+	}
+
+	return origin
+}
+
+// newRequestID returns a random 128-bit hex encoded ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// originName describes the client which made a copy as user@hostname
+func originName(origin *pb.CopyOrigin) string {
+	if origin == nil {
+		return "-"
+	}
+
+	return origin.GetUser() + "@" + origin.GetHostname()
+}
@@ -0,0 +1,420 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// copyQueue keeps the copies which couldn't reach the server in a JSON lines file,
+// until they're flushed on the next successful connection.
+// A nil copyQueue keeps nothing.
+type copyQueue struct {
+	path string
+}
+
+// queueMutex serializes the changes to the queue made by concurrent copies to several servers.
+// The lock file serializes them across the vimonade processes sharing the queue.
+var queueMutex sync.Mutex
+
+// queuedCopy is a copy waiting for its server to be reachable
+type queuedCopy struct {
+	Host     string    `json:"host"`
+	Port     int       `json:"port"`
+	QueuedAt time.Time `json:"queued_at"`
+	// Request is the protobuf encoded CopyRequest
+	Request []byte `json:"request"`
+}
+
+// newCopyQueue returns a copyQueue in the user state directory, or nil if there is none
+func newCopyQueue() *copyQueue {
+	dir, err := stateDir()
+	if err != nil {
+		return nil
+	}
+
+	return &copyQueue{path: filepath.Join(dir, "queue.jsonl")}
+}
+
+// stateDir returns $XDG_STATE_HOME/vimonade, or ~/.vimonade where the server keeps its state
+func stateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "vimonade"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".vimonade"), nil
+}
+
+// lock holds the queue for the process until the returned function is called,
+// so that no copy is lost or sent twice by concurrent copies and flushes
+func (q *copyQueue) lock() (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(q.path), 0700); err != nil {
+		return nil, err
+	}
+
+	queueMutex.Lock()
+
+	file, err := os.OpenFile(q.path+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		queueMutex.Unlock()
+		return nil, err
+	}
+
+	if err := lockFile(file); err != nil {
+		file.Close()
+		queueMutex.Unlock()
+
+		return nil, fmt.Errorf("cannot lock copy queue: %s", err)
+	}
+
+	return func() {
+		unlockFile(file)
+		file.Close()
+		queueMutex.Unlock()
+	}, nil
+}
+
+// push appends a copy to the queue
+func (q *copyQueue) push(entry *queuedCopy) error {
+	if q == nil {
+		return fmt.Errorf("cannot find a state directory for the copy queue")
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := q.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := os.OpenFile(q.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(b, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// load returns the queued copies, oldest first. Changing them requires the lock.
+func (q *copyQueue) load() ([]*queuedCopy, error) {
+	if q == nil {
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadFile(q.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*queuedCopy
+
+	reader := bufio.NewReader(bytes.NewReader(b))
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			entry := &queuedCopy{}
+			if err := json.Unmarshal(line, entry); err != nil {
+				return nil, fmt.Errorf("cannot decode copy queue: %s", err)
+			}
+
+			entries = append(entries, entry)
+		}
+
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// save replaces the queued copies, removing the queue when there are none left
+func (q *copyQueue) save(entries []*queuedCopy) error {
+	if q == nil {
+		return nil
+	}
+
+	if len(entries) == 0 {
+		return q.remove()
+	}
+
+	var buf bytes.Buffer
+
+	for _, entry := range entries {
+		b, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(append(b, '\n'))
+	}
+
+	tmp := q.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, q.path)
+}
+
+// clear removes every queued copy, holding the lock
+func (q *copyQueue) clear() error {
+	if q == nil {
+		return nil
+	}
+
+	unlock, err := q.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return q.remove()
+}
+
+// remove deletes the queue file
+func (q *copyQueue) remove() error {
+	if err := os.Remove(q.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// queueCopy keeps a copy which couldn't reach the server to send it later
+func (c *client) queueCopy(req *pb.CopyRequest) {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		c.logger.Error("error queuing copy: " + err.Error())
+		return
+	}
+
+	if err := c.queue.push(&queuedCopy{Host: c.host, Port: c.port, QueuedAt: time.Now(), Request: b}); err != nil {
+		c.logger.Error("error queuing copy: " + err.Error())
+		return
+	}
+
+	c.logger.Debug("server unreachable, copy queued")
+}
+
+// flushQueue sends the copies queued for the server in order, or only the latest copy
+// to each register when collapsing. Copies are kept while the server can't be reached,
+// and dropped when it rejects them. It returns the number of copies sent.
+// The queue stays locked while they're sent, so that no other process sends them too.
+func (c *client) flushQueue(collapse bool) (int, error) {
+	unlock, err := c.queue.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	entries, err := c.queue.load()
+	if err != nil || len(entries) == 0 {
+		return 0, err
+	}
+
+	var (
+		kept    []*queuedCopy
+		pending []*queuedCopy
+		latest  = make(map[string]int)
+	)
+
+	for _, entry := range entries {
+		if entry.Host != c.host || entry.Port != c.port {
+			kept = append(kept, entry)
+			continue
+		}
+
+		pending = append(pending, entry)
+	}
+
+	requests := make([]*pb.CopyRequest, len(pending))
+
+	for i, entry := range pending {
+		req := &pb.CopyRequest{}
+		if err := proto.Unmarshal(entry.Request, req); err != nil {
+			return 0, fmt.Errorf("cannot decode queued copy: %s", err)
+		}
+
+		requests[i] = req
+		latest[queueKey(req)] = i
+	}
+
+	sent := 0
+
+	for i, req := range requests {
+		if collapse && latest[queueKey(req)] != i {
+			continue
+		}
+
+		if _, err := c.copy(req); err != nil {
+			if isUnreachable(err) {
+				kept = append(kept, pending[i:]...)
+				break
+			}
+
+			c.logger.Error("dropping queued copy rejected by the server: " + err.Error())
+
+			continue
+		}
+
+		sent++
+	}
+
+	return sent, c.queue.save(kept)
+}
+
+// queueKey identifies the clipboard a queued copy replaces
+func queueKey(req *pb.CopyRequest) string {
+	register := req.GetRegister()
+	if lemon.IsClipboardRegister(register) {
+		register = "+"
+	}
+
+	selection, _ := lemon.NormalizeSelection(req.GetSelection())
+
+	return register + " " + selection + " " + req.GetChannel()
+}
+
+func Queue(c *lemon.CLI, logger *zap.Logger, opts ...grpc.DialOption) int {
+	action, err := parseQueueArgs(c.Args)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	if err := queue(c, logger, action, opts...); err != nil {
+		logger.Debug("failed to " + action + " queue: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+
+	return lemon.Success
+}
+
+// parseQueueArgs validates `queue [ls|flush|clear]`
+func parseQueueArgs(args []string) (string, error) {
+	if len(args) == 0 {
+		return "ls", nil
+	}
+
+	if len(args) != 1 {
+		return "", fmt.Errorf("queue %s takes no argument", args[0])
+	}
+
+	switch args[0] {
+	case "list", "ls", "flush", "clear":
+		return args[0], nil
+	default:
+		return "", fmt.Errorf("unknown queue command: %s", args[0])
+	}
+}
+
+func queue(c *lemon.CLI, logger *zap.Logger, action string, opts ...grpc.DialOption) error {
+	q := newCopyQueue()
+
+	switch action {
+	case "list", "ls":
+		entries, err := q.load()
+		if err != nil {
+			return err
+		}
+
+		for i, entry := range entries {
+			req := &pb.CopyRequest{}
+			if err := proto.Unmarshal(entry.Request, req); err != nil {
+				return fmt.Errorf("cannot decode queued copy: %s", err)
+			}
+
+			text, size := req.GetValue(), len(req.GetValue())
+			if payloads := req.GetPayloads(); len(payloads) > 0 {
+				text, size = "<"+payloads[0].GetMimeType()+">", len(payloads[0].GetData())
+			}
+
+			fmt.Fprintf(c.Out, "%3d  %s  %-20s %8dB  %-2s %s\n",
+				i+1,
+				entry.QueuedAt.Format("2006-01-02 15:04:05"),
+				fmt.Sprintf("%s:%d", entry.Host, entry.Port),
+				size,
+				req.GetRegister(),
+				lemon.Preview(text))
+		}
+	case "flush":
+		entries, err := q.load()
+		if err != nil {
+			return err
+		}
+
+		// every server with queued copies is flushed, not only --host
+		flushed := make(map[string]bool)
+
+		for _, entry := range entries {
+			target := fmt.Sprintf("%s:%d", entry.Host, entry.Port)
+			if flushed[target] {
+				continue
+			}
+
+			flushed[target] = true
+
+			conn, err := dial(target, opts...)
+			if err != nil {
+				logger.Debug("failed to dial server: " + err.Error())
+				fmt.Fprintln(c.Err, "cannot reach "+target+", its copies stay queued")
+
+				continue
+			}
+
+			lc := New(c, conn, logger)
+			lc.host, lc.port = entry.Host, entry.Port
+
+			_, err = lc.flushQueue(c.CollapseQueue)
+			conn.Close()
+
+			if err != nil {
+				return err
+			}
+		}
+	case "clear":
+		return q.clear()
+	}
+
+	return nil
+}
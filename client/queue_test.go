@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+)
+
+// fakeServer records the copies it receives, failing those listed in errors
+type fakeServer struct {
+	pb.VimonadeServiceClient
+
+	mutex  sync.Mutex
+	copies []string
+	errors map[string]error
+}
+
+func (s *fakeServer) Copy(ctx context.Context, req *pb.CopyRequest, opts ...grpc.CallOption) (*pb.CopyResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.errors[req.GetValue()]; err != nil {
+		return nil, err
+	}
+
+	s.copies = append(s.copies, req.GetValue())
+
+	return &pb.CopyResponse{}, nil
+}
+
+func newTestQueue(t *testing.T) (*copyQueue, func()) {
+	dir, err := ioutil.TempDir("", "vimonade")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &copyQueue{path: filepath.Join(dir, "queue.jsonl")}, func() { os.RemoveAll(dir) }
+}
+
+func newQueueClient(q *copyQueue, server *fakeServer) *client {
+	return &client{host: "localhost", port: 2489, queue: q, logger: zap.NewNop(), grpcClient: server}
+}
+
+// pushCopies queues copies of values to register of host:port
+func pushCopies(t *testing.T, q *copyQueue, host string, port int, register string, values ...string) {
+	for _, value := range values {
+		b, err := proto.Marshal(&pb.CopyRequest{Value: value, Register: register})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := q.push(&queuedCopy{Host: host, Port: port, Request: b}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// queuedValues returns the values of the queued copies, oldest first
+func queuedValues(t *testing.T, q *copyQueue) []string {
+	entries, err := q.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values []string
+
+	for _, entry := range entries {
+		req := &pb.CopyRequest{}
+		if err := proto.Unmarshal(entry.Request, req); err != nil {
+			t.Fatal(err)
+		}
+
+		values = append(values, req.GetValue())
+	}
+
+	return values
+}
+
+func TestFlushQueue(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		collapse bool
+		errors   map[string]error
+		sent     []string
+		kept     []string
+	}{
+		{name: "in order", sent: []string{"hoge", "fuga", "piyo"}, kept: []string{"other"}},
+		{name: "collapsed", collapse: true, sent: []string{"fuga", "piyo"}, kept: []string{"other"}},
+		{
+			name:   "unreachable",
+			errors: map[string]error{"fuga": status.Error(codes.Unavailable, "unreachable")},
+			sent:   []string{"hoge"},
+			kept:   []string{"other", "fuga", "piyo"},
+		},
+		{
+			name:   "rejected",
+			errors: map[string]error{"fuga": status.Error(codes.InvalidArgument, "rejected")},
+			sent:   []string{"hoge", "piyo"},
+			kept:   []string{"other"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, cleanup := newTestQueue(t)
+			defer cleanup()
+
+			pushCopies(t, q, "localhost", 2489, "", "hoge", "fuga")
+			pushCopies(t, q, "remote", 2489, "", "other")
+			pushCopies(t, q, "localhost", 2489, "a", "piyo")
+
+			server := &fakeServer{errors: tt.errors}
+
+			sent, err := newQueueClient(q, server).flushQueue(tt.collapse)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sent != len(tt.sent) || !reflect.DeepEqual(server.copies, tt.sent) {
+				t.Errorf("Expected %v to be sent, got %v (%d)", tt.sent, server.copies, sent)
+			}
+
+			// the copies kept for the server stay in order, after those of the other servers
+			if kept := queuedValues(t, q); !reflect.DeepEqual(kept, tt.kept) {
+				t.Errorf("Expected %v to stay queued, got %v", tt.kept, kept)
+			}
+		})
+	}
+}
+
+func TestConcurrentQueue(t *testing.T) {
+	q, cleanup := newTestQueue(t)
+	defer cleanup()
+
+	server := &fakeServer{}
+	values := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	var wg sync.WaitGroup
+
+	for _, value := range values {
+		wg.Add(2)
+
+		go func(value string) {
+			defer wg.Done()
+			pushCopies(t, q, "localhost", 2489, "", value)
+		}(value)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := newQueueClient(q, server).flushQueue(false); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// every copy is either sent once or still queued
+	seen := make(map[string]int)
+	for _, value := range append(server.copies, queuedValues(t, q)...) {
+		seen[value]++
+	}
+
+	for _, value := range values {
+		if seen[value] != 1 {
+			t.Errorf("Expected %s once, got %d times", value, seen[value])
+		}
+	}
+}
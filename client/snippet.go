@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+func Snippet(c *lemon.CLI, logger *zap.Logger, opts ...grpc.DialOption) int {
+	action, name, err := parseSnippetArgs(c.Args)
+	if err != nil {
+		writeError(c, err)
+		return lemon.FlagParseError
+	}
+
+	conn, err := grpc.Dial(address(c), opts...)
+	if err != nil {
+		logger.Error("failed to dial server: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+	defer conn.Close()
+
+	lc := New(c, conn, logger)
+
+	text := c.DataSource
+	if len(c.Args) == 3 {
+		text = c.Args[2]
+	}
+
+	if err := lc.snippet(c.Out, action, name, text); err != nil {
+		logger.Debug("failed to " + action + " snippet: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+
+	return lemon.Success
+}
+
+// parseSnippetArgs validates `snippet [ls|get NAME|save NAME [text]|rm NAME]`
+func parseSnippetArgs(args []string) (string, string, error) {
+	if len(args) == 0 {
+		return "ls", "", nil
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		if len(args) != 1 {
+			return "", "", fmt.Errorf("snippet %s takes no argument", args[0])
+		}
+
+		return "ls", "", nil
+	case "get", "rm":
+		if len(args) != 2 {
+			return "", "", fmt.Errorf("snippet %s takes a name", args[0])
+		}
+
+		return args[0], args[1], nil
+	case "save":
+		if len(args) != 2 && len(args) != 3 {
+			return "", "", fmt.Errorf("snippet save takes a name and an optional text")
+		}
+
+		return args[0], args[1], nil
+	default:
+		return "", "", fmt.Errorf("unknown snippet command: %s", args[0])
+	}
+}
+
+func (c *client) snippet(out io.Writer, action, name, text string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	defer cancel()
+
+	switch action {
+	case "ls":
+		res, err := c.grpcClient.ListSnippets(ctx, &pb.ListSnippetsRequest{})
+		if err != nil {
+			return err
+		}
+
+		for _, snippet := range res.GetSnippets() {
+			fmt.Fprintf(out, "%-20s  %s  %8dB  %s\n",
+				snippet.GetName(),
+				time.Unix(snippet.GetUpdatedAt(), 0).Format("2006-01-02 15:04:05"),
+				snippet.GetSize(),
+				snippet.GetPreview())
+		}
+	case "get":
+		res, err := c.grpcClient.GetSnippet(ctx, &pb.GetSnippetRequest{Name: name})
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(out, lemon.ConvertLineEnding(string(res.GetSnippet().GetData()), c.lineEnding))
+
+		return err
+	case "save":
+		_, err := c.grpcClient.SaveSnippet(ctx, &pb.SaveSnippetRequest{Name: name, Data: []byte(text)})
+		return err
+	case "rm":
+		_, err := c.grpcClient.DeleteSnippet(ctx, &pb.DeleteSnippetRequest{Name: name})
+		return err
+	}
+
+	return nil
+}
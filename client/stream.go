@@ -0,0 +1,177 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+)
+
+const (
+	// content past streamThreshold is copied with CopyStream,
+	// well below the default gRPC message limit of 4MB
+	streamThreshold = 1 << 20
+	chunkSize       = 1 << 16
+)
+
+// idleTimeout cancels a stream which makes no progress for a while,
+// however long the whole transfer takes
+type idleTimeout struct {
+	timeout time.Duration
+	timer   *time.Timer
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func newIdleTimeout(timeout time.Duration) *idleTimeout {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &idleTimeout{
+		timeout: timeout,
+		timer:   time.AfterFunc(timeout, cancel),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Context returns the context of the stream
+func (t *idleTimeout) Context() context.Context {
+	return t.ctx
+}
+
+// touch records progress, restarting the timeout
+func (t *idleTimeout) touch() {
+	t.timer.Reset(t.timeout)
+}
+
+// stop releases the stream
+func (t *idleTimeout) stop() {
+	t.timer.Stop()
+	t.cancel()
+}
+
+// err reports a stream canceled by the timeout as DeadlineExceeded, as a deadline would
+func (t *idleTimeout) err(err error) error {
+	if err != nil && t.ctx.Err() != nil && status.Code(err) == codes.Canceled {
+		return status.Errorf(codes.DeadlineExceeded, "no progress for %s", t.timeout)
+	}
+
+	return err
+}
+
+// copy sends req with Copy, or chunked with CopyStream when it's too large for a single message
+func (c *client) copy(req *pb.CopyRequest) (*pb.CopyResponse, error) {
+	data := []byte(req.GetValue())
+	info := &pb.CopyRequest{
+		Register:  req.GetRegister(),
+		Regtype:   req.GetRegtype(),
+		Selection: req.GetSelection(),
+		Channel:   req.GetChannel(),
+		Sensitive: req.GetSensitive(),
+		Ttl:       req.GetTtl(),
+		Origin:    req.GetOrigin(),
+	}
+
+	if payloads := req.GetPayloads(); len(payloads) == 1 {
+		data = payloads[0].GetData()
+		info.Payloads = []*pb.Payload{{MimeType: payloads[0].GetMimeType()}}
+	}
+
+	if len(data) <= streamThreshold {
+		ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+		defer cancel()
+
+		return c.grpcClient.Copy(ctx, req)
+	}
+
+	idle := newIdleTimeout(timeOut)
+	defer idle.stop()
+
+	stream, err := c.grpcClient.CopyStream(idle.Context())
+	if err != nil {
+		return nil, idle.err(err)
+	}
+
+	if err := stream.Send(&pb.CopyChunk{Data: &pb.CopyChunk_Info{Info: info}}); err != nil {
+		return nil, idle.err(err)
+	}
+
+	for len(data) > 0 {
+		n := chunkSize
+		if n > len(data) {
+			n = len(data)
+		}
+
+		if err := stream.Send(&pb.CopyChunk{Data: &pb.CopyChunk_ChunkData{ChunkData: data[:n]}}); err != nil {
+			return nil, idle.err(err)
+		}
+
+		idle.touch()
+
+		data = data[n:]
+	}
+
+	res, err := stream.CloseAndRecv()
+
+	return res, idle.err(err)
+}
+
+// paste requests req with PasteStream, which answers content of any size in a single read
+// of the clipboard, falling back to Paste with servers which can't stream pastes
+func (c *client) paste(req *pb.PasteRequest) (*pb.PasteResponse, error) {
+	idle := newIdleTimeout(timeOut)
+	defer idle.stop()
+
+	stream, err := c.grpcClient.PasteStream(idle.Context(), req)
+	if err != nil {
+		return nil, idle.err(err)
+	}
+
+	chunk, err := stream.Recv()
+	if status.Code(err) == codes.Unimplemented {
+		c.logger.Debug("the server can't stream pastes: " + err.Error())
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+		defer cancel()
+
+		return c.grpcClient.Paste(ctx, req)
+	}
+	if err != nil {
+		return nil, idle.err(err)
+	}
+
+	res := chunk.GetInfo()
+	if res == nil {
+		return nil, fmt.Errorf("the server sent no paste info")
+	}
+
+	data := bytes.Buffer{}
+
+	for {
+		idle.touch()
+
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, idle.err(err)
+		}
+
+		data.Write(chunk.GetChunkData())
+	}
+
+	if payloads := res.GetPayloads(); len(payloads) > 0 {
+		payloads[0].Data = data.Bytes()
+	} else {
+		res.Value = data.String()
+	}
+
+	return res, nil
+}
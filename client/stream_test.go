@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+)
+
+// pasteStreamServer streams chunks as any paste
+type pasteStreamServer struct {
+	pb.VimonadeServiceClient
+
+	chunks []*pb.PasteChunk
+}
+
+func (s *pasteStreamServer) PasteStream(ctx context.Context, req *pb.PasteRequest, opts ...grpc.CallOption) (pb.VimonadeService_PasteStreamClient, error) {
+	return &pasteStream{chunks: s.chunks}, nil
+}
+
+type pasteStream struct {
+	grpc.ClientStream
+
+	chunks []*pb.PasteChunk
+}
+
+func (s *pasteStream) Recv() (*pb.PasteChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+
+	return chunk, nil
+}
+
+func TestIdleTimeout(t *testing.T) {
+	idle := newIdleTimeout(50 * time.Millisecond)
+	defer idle.stop()
+
+	// progress keeps the stream alive past the timeout
+	for i := 0; i < 4; i++ {
+		time.Sleep(20 * time.Millisecond)
+		idle.touch()
+	}
+
+	if err := idle.Context().Err(); err != nil {
+		t.Fatalf("Expected the stream to be alive, got %v", err)
+	}
+
+	<-idle.Context().Done()
+
+	canceled := status.FromContextError(context.Canceled).Err()
+	if err := idle.err(canceled); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestPasteStream(t *testing.T) {
+	data := &pb.PasteChunk{Data: &pb.PasteChunk_ChunkData{ChunkData: []byte("hoge")}}
+
+	c := &client{logger: zap.NewNop(), grpcClient: &pasteStreamServer{chunks: []*pb.PasteChunk{
+		{Data: &pb.PasteChunk_Info{Info: &pb.PasteResponse{Regtype: "v"}}},
+		data,
+	}}}
+
+	if res, err := c.paste(&pb.PasteRequest{}); err != nil || res.GetValue() != "hoge" {
+		t.Errorf("Expected: %q, got %v (%v)", "hoge", res, err)
+	}
+
+	// a stream starting without the paste info fails instead of panicking
+	c.grpcClient = &pasteStreamServer{chunks: []*pb.PasteChunk{data}}
+
+	if _, err := c.paste(&pb.PasteRequest{}); err == nil {
+		t.Error("Expected an error for a stream without paste info")
+	}
+}
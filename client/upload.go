@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+)
+
+// uploadID identifies the upload of a file, until the file is modified
+func uploadID(path string, info os.FileInfo) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%d", path, info.Size(), info.ModTime().UnixNano())))
+
+	return hex.EncodeToString(sum[:16])
+}
+
+// fileDigest returns the hex SHA-256 digest of a file, which is read from its start
+func fileDigest(file *os.File) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// uploadOffset returns the number of bytes the server already received for an upload,
+// 0 if it's new or the server can't resume uploads
+func (c *client) uploadOffset(id string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	defer cancel()
+
+	res, err := c.grpcClient.UploadStatus(ctx, &pb.UploadStatusRequest{UploadId: id})
+	switch status.Code(err) {
+	case codes.OK:
+		return int64(res.GetOffset()), nil
+	case codes.NotFound, codes.Unimplemented:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
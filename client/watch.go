@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// watchEvent is a clipboard change printed as a JSON line
+type watchEvent struct {
+	Register  string `json:"register"`
+	Value     string `json:"value,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+	Origin    string `json:"origin"`
+	Timestamp string `json:"timestamp"`
+	Size      uint64 `json:"size"`
+	Regtype   string `json:"regtype"`
+	Dropped   uint64 `json:"dropped,omitempty"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+	Channel   string `json:"channel,omitempty"`
+	// Client is the client which made the copy
+	Client *pb.CopyOrigin `json:"client,omitempty"`
+}
+
+func Watch(c *lemon.CLI, logger *zap.Logger, opts ...grpc.DialOption) int {
+	conn, err := grpc.Dial(address(c), opts...)
+	if err != nil {
+		logger.Error("failed to dial server: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+	defer conn.Close()
+
+	lc := New(c, conn, logger)
+
+	if err := lc.watch(c.Out, c.DigestOnly); err != nil {
+		logger.Debug("failed to watch: " + err.Error())
+		writeError(c, err)
+
+		return lemon.RPCError
+	}
+
+	return lemon.Success
+}
+
+// watch prints every clipboard event until the server closes the stream
+func (c *client) watch(out io.Writer, digestOnly bool) error {
+	stream, err := c.grpcClient.Watch(context.Background(), &pb.WatchRequest{
+		Register:   c.register,
+		DigestOnly: digestOnly,
+		Channel:    c.channel,
+		Origin:     newCopyOrigin(),
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(&watchEvent{
+			Register:  event.GetRegister(),
+			Value:     event.GetValue(),
+			Digest:    event.GetDigest(),
+			Origin:    event.GetOrigin(),
+			Timestamp: time.Unix(0, event.GetTimestamp()*int64(time.Millisecond)).Format(time.RFC3339Nano),
+			Size:      event.GetSize(),
+			Regtype:   event.GetRegtype(),
+			Dropped:   event.GetDropped(),
+			Sensitive: event.GetSensitive(),
+			Channel:   event.GetChannel(),
+			Client:    event.GetClient(),
+		}); err != nil {
+			return err
+		}
+	}
+}
@@ -54,6 +54,36 @@ func Do(c *lemon.CLI, args []string) int {
 			grpc.WithBlock(),
 			grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: 1 * time.Second}))
 
+	case lemon.GET:
+		logger.Debug("Getting file")
+		return vc.Get(c, logger, grpc.WithInsecure(),
+			grpc.WithBlock(),
+			grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: 1 * time.Second}))
+
+	case lemon.HISTORY:
+		logger.Debug("Managing history")
+		return vc.History(c, logger, grpc.WithInsecure(),
+			grpc.WithBlock(),
+			grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: 1 * time.Second}))
+
+	case lemon.WATCH:
+		logger.Debug("Watching clipboard")
+		return vc.Watch(c, logger, grpc.WithInsecure(),
+			grpc.WithBlock(),
+			grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: 1 * time.Second}))
+
+	case lemon.SNIPPET:
+		logger.Debug("Managing snippets")
+		return vc.Snippet(c, logger, grpc.WithInsecure(),
+			grpc.WithBlock(),
+			grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: 1 * time.Second}))
+
+	case lemon.QUEUE:
+		logger.Debug("Managing copy queue")
+		return vc.Queue(c, logger, grpc.WithInsecure(),
+			grpc.WithBlock(),
+			grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: 1 * time.Second}))
+
 	case lemon.SERVER:
 		logger.Debug("Starting Server")
 		return vs.Serve(c, nil, logger)
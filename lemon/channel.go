@@ -0,0 +1,47 @@
+package lemon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxChannelName is the maximum length of a channel name
+const maxChannelName = 64
+
+// NormalizeChannel returns the shared channel named by channel, the system clipboard when empty.
+// Channel names are case insensitive letters, digits, '.', '-' and '_'.
+func NormalizeChannel(channel string) (string, error) {
+	name := strings.ToLower(channel)
+
+	if len(name) > maxChannelName {
+		return "", fmt.Errorf("invalid channel: %q", channel)
+	}
+
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+		default:
+			return "", fmt.Errorf("invalid channel: %q", channel)
+		}
+	}
+
+	return name, nil
+}
+
+// ParseChannels returns the channels of a comma separated list
+func ParseChannels(list string) ([]string, error) {
+	var channels []string
+
+	for _, channel := range strings.Split(list, ",") {
+		name, err := NormalizeChannel(strings.TrimSpace(channel))
+		if err != nil {
+			return nil, err
+		}
+
+		if name != "" {
+			channels = append(channels, name)
+		}
+	}
+
+	return channels, nil
+}
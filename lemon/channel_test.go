@@ -0,0 +1,48 @@
+package lemon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeChannel(t *testing.T) {
+	assert := func(channel, expected string) {
+		got, err := NormalizeChannel(channel)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != expected {
+			t.Errorf("Expected: %q, got %q", expected, got)
+		}
+	}
+
+	assert("", "")
+	assert("pair", "pair")
+	assert("Pair-1.review_b", "pair-1.review_b")
+
+	for _, channel := range []string{"a b", "a/b", "ペア", string(make([]byte, 65))} {
+		if _, err := NormalizeChannel(channel); err == nil {
+			t.Errorf("Expected an error for %q", channel)
+		}
+	}
+}
+
+func TestParseChannels(t *testing.T) {
+	channels, err := ParseChannels("pair, Review,,")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"pair", "review"}; !reflect.DeepEqual(channels, expected) {
+		t.Errorf("Expected: %v, got %v", expected, channels)
+	}
+
+	if channels, err := ParseChannels(""); err != nil || channels != nil {
+		t.Errorf("Expected no channels, got %v (%v)", channels, err)
+	}
+
+	if _, err := ParseChannels("pair,a b"); err == nil {
+		t.Error("Expected an error for an invalid channel")
+	}
+}
@@ -0,0 +1,71 @@
+package lemon
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// Charset converts text between UTF-8 and another character set, e.g. UTF-16LE or Shift_JIS.
+// A nil Charset leaves text as is.
+type Charset struct {
+	name     string
+	encoding encoding.Encoding
+}
+
+// LookupCharset returns the character set named by name, or nil for UTF-8.
+// Names are the WHATWG encoding labels like utf-16le, shift_jis or euc-jp.
+func LookupCharset(name string) (*Charset, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	switch name {
+	case "", "utf-8", "utf8":
+		return nil, nil
+	}
+
+	enc, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown charset: %s", name)
+	}
+
+	return &Charset{name: name, encoding: enc}, nil
+}
+
+// Encode converts UTF-8 text to the character set
+func (c *Charset) Encode(text string) (string, error) {
+	if c == nil {
+		return text, nil
+	}
+
+	encoded, err := c.encoding.NewEncoder().String(text)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode text to %s: %s", c.name, err)
+	}
+
+	return encoded, nil
+}
+
+// Decode converts text in the character set to UTF-8
+func (c *Charset) Decode(text string) (string, error) {
+	if c == nil {
+		return text, nil
+	}
+
+	decoded, err := c.encoding.NewDecoder().String(text)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode text from %s: %s", c.name, err)
+	}
+
+	return decoded, nil
+}
+
+// String returns the name of the character set
+func (c *Charset) String() string {
+	if c == nil {
+		return "utf-8"
+	}
+
+	return c.name
+}
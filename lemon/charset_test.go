@@ -0,0 +1,54 @@
+package lemon
+
+import "testing"
+
+func TestCharset(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		text    string
+		encoded string
+	}{
+		{"", "日本語", "日本語"},
+		{"UTF-8", "日本語", "日本語"},
+		{"utf-16le", "aé日", "a\x00\xe9\x00\xe5\x65"},
+		{"shift_jis", "日本語\n", "\x93\xfa\x96\x7b\x8c\xea\n"},
+		{"euc-jp", "日本語", "\xc6\xfc\xcb\xdc\xb8\xec"},
+		{"windows-1252", "café", "caf\xe9"},
+	} {
+		charset, err := LookupCharset(tc.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := charset.Encode(tc.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if encoded != tc.encoded {
+			t.Errorf("%s: Expected: %q, got %q", tc.name, tc.encoded, encoded)
+		}
+
+		decoded, err := charset.Decode(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if decoded != tc.text {
+			t.Errorf("%s: Expected: %q, got %q", tc.name, tc.text, decoded)
+		}
+	}
+
+	if _, err := LookupCharset("klingon"); err == nil {
+		t.Error("Expected an error for an unknown charset")
+	}
+
+	charset, err := LookupCharset("shift_jis")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := charset.Encode("🍣"); err == nil {
+		t.Error("Expected an error for text which can't be encoded")
+	}
+}
@@ -1,6 +1,9 @@
 package lemon
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 type CommandType int
 
@@ -10,6 +13,11 @@ const (
 	PASTE
 	SERVER
 	SEND
+	HISTORY
+	WATCH
+	SNIPPET
+	QUEUE
+	GET
 )
 
 const (
@@ -32,6 +40,7 @@ type CLI struct {
 
 	Type       CommandType
 	DataSource string
+	Args       []string
 
 	// options
 	Port        int
@@ -39,7 +48,45 @@ type CLI struct {
 	Host        string
 	LineEnding  string
 	VimonadeDir string
+	StateDir    string
+	OnCollision string
+	MaxFileSize int64
+	UploadTTL   time.Duration
 	LogLevel    int
+	Register    string
+	Regtype     string
+	Selection   string
+	Channel     string
+	Format      string
+	MimeType    string
+	Trim        bool
+	SkipBlank   bool
+	Sensitive   bool
+	TTL         time.Duration
+	Index       int
+	HistorySize int
+	DigestOnly  bool
+	Force       bool
+
+	CollapseQueue bool
+	PasteFrom     string
+
+	WatchInterval time.Duration
+
+	ClipboardBackend string
+	CopyCommand      string
+	PasteCommand     string
+
+	SecretFilter string
+	SecretRules  string
+
+	Transform      string
+	CopyTransform  string
+	PasteTransform string
+
+	Charset string
+
+	BridgeChannels string
 
 	Help bool
 }
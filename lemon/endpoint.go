@@ -0,0 +1,56 @@
+package lemon
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Endpoint is the address of a vimonade server
+type Endpoint struct {
+	Host string
+	Port int
+}
+
+func (e Endpoint) String() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+}
+
+// ParseEndpoints returns the servers of a comma separated list of host or host:port,
+// port being the port of hosts without one
+func ParseEndpoints(hosts string, port int) ([]Endpoint, error) {
+	var endpoints []Endpoint
+
+	for _, host := range strings.Split(hosts, ",") {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+
+		endpoint := Endpoint{Host: host, Port: port}
+
+		// a bare IPv6 address has more than one colon
+		if strings.HasPrefix(host, "[") || strings.Count(host, ":") == 1 {
+			h, p, err := net.SplitHostPort(host)
+			if err != nil {
+				return nil, fmt.Errorf("invalid host: %q", host)
+			}
+
+			n, err := strconv.Atoi(p)
+			if err != nil || n <= 0 || n > 65535 {
+				return nil, fmt.Errorf("invalid port: %q", host)
+			}
+
+			endpoint = Endpoint{Host: h, Port: n}
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no host given")
+	}
+
+	return endpoints, nil
+}
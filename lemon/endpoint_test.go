@@ -0,0 +1,34 @@
+package lemon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEndpoints(t *testing.T) {
+	endpoints, err := ParseEndpoints("localhost, work:2490,[::1]:2491,fe80::1", 2489)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Endpoint{
+		{Host: "localhost", Port: 2489},
+		{Host: "work", Port: 2490},
+		{Host: "::1", Port: 2491},
+		{Host: "fe80::1", Port: 2489},
+	}
+
+	if !reflect.DeepEqual(endpoints, expected) {
+		t.Errorf("Expected: %v, got %v", expected, endpoints)
+	}
+
+	if s := endpoints[2].String(); s != "[::1]:2491" {
+		t.Errorf("Expected: %q, got %q", "[::1]:2491", s)
+	}
+
+	for _, hosts := range []string{"", ",", "work:port", "work:0", "[::1"} {
+		if _, err := ParseEndpoints(hosts, 2489); err == nil {
+			t.Errorf("Expected an error for %q", hosts)
+		}
+	}
+}
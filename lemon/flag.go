@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"regexp"
+	"time"
 )
 
 func (c *CLI) FlagParse(args []string, skip bool) error {
@@ -54,6 +55,26 @@ func (c *CLI) getCommandType(args []string) (s CommandStyle, err error) {
 			c.Type = SERVER
 			del(i)
 			return
+		case "history":
+			c.Type = HISTORY
+			del(i)
+			return
+		case "watch":
+			c.Type = WATCH
+			del(i)
+			return
+		case "snippet":
+			c.Type = SNIPPET
+			del(i)
+			return
+		case "queue":
+			c.Type = QUEUE
+			del(i)
+			return
+		case "get":
+			c.Type = GET
+			del(i)
+			return
 		}
 	}
 
@@ -64,11 +85,42 @@ func (c *CLI) flags() *flag.FlagSet {
 	flags := flag.NewFlagSet("vimonade", flag.ContinueOnError)
 	flags.IntVar(&c.Port, "port", 2489, "TCP port number")
 	flags.StringVar(&c.Allow, "allow", "0.0.0.0/0,::/0", "Allow IP range")
-	flags.StringVar(&c.Host, "host", "localhost", "Destination host name.")
+	flags.StringVar(&c.Host, "host", "localhost", "Comma separated destination host names, with an optional :port")
 	flags.BoolVar(&c.Help, "help", false, "Show this message")
 	flags.StringVar(&c.LineEnding, "line-ending", "", "Convert Line Endings (CR/CRLF)")
 	flags.StringVar(&c.VimonadeDir, "vimonade-dir", "", "directory for storing files from remote client")
+	flags.StringVar(&c.StateDir, "state-dir", "", "Directory of the registers, history, snippets and clipboard file of the server, ~/.vimonade by default")
+	flags.StringVar(&c.OnCollision, "on-collision", "rename", "Policy for sent files named like a stored file (rename/overwrite/reject)")
+	flags.Int64Var(&c.MaxFileSize, "max-file-size", 1<<30, "Size limit in bytes of sent files, 0 for no limit")
+	flags.DurationVar(&c.UploadTTL, "upload-ttl", 24*time.Hour, "Time an interrupted upload is kept to be resumed, 0 to keep it forever")
 	flags.IntVar(&c.LogLevel, "log-level", 1, "Log level")
+	flags.StringVar(&c.Register, "register", "", "Vim register to copy to or paste from")
+	flags.StringVar(&c.Regtype, "regtype", "", "Vim register type of the copied text (v/V/b{width})")
+	flags.StringVar(&c.Selection, "selection", "clipboard", "X11 selection to copy to or paste from (clipboard/primary/secondary)")
+	flags.StringVar(&c.Channel, "channel", "", "Shared channel to copy to, paste from or watch instead of the system clipboard")
+	flags.StringVar(&c.Format, "format", "text", "Paste output format (text/json)")
+	flags.IntVar(&c.Index, "index", 0, "Paste the Nth newest history entry")
+	flags.StringVar(&c.MimeType, "type", "", "MIME type of the copied or pasted content, e.g. image/png")
+	flags.BoolVar(&c.Trim, "trim", false, "Trim leading and trailing white space before copying")
+	flags.BoolVar(&c.SkipBlank, "skip-blank", false, "Don't copy empty or blank text")
+	flags.BoolVar(&c.Sensitive, "sensitive", false, "Keep the copy out of logs and history, and expire it after --ttl or 30s")
+	flags.DurationVar(&c.TTL, "ttl", 0, "Restore the previous clipboard content after this duration, e.g. 30s")
+	flags.IntVar(&c.HistorySize, "history-size", 100, "Number of copied entries kept in history")
+	flags.BoolVar(&c.DigestOnly, "digest", false, "Watch sha256 digests instead of values")
+	flags.BoolVar(&c.Force, "force", false, "Overwrite the destination of a downloaded file")
+	flags.StringVar(&c.PasteFrom, "paste-from", "first", "Server to paste from when --host lists several (first/newest). newest compares the clocks of the servers")
+	flags.BoolVar(&c.CollapseQueue, "collapse-queue", false, "Only flush the latest queued copy to each register")
+	flags.DurationVar(&c.WatchInterval, "watch-interval", 500*time.Millisecond, "Interval between checks of the host clipboard")
+	flags.StringVar(&c.ClipboardBackend, "clipboard-backend", "system", "Server clipboard backend (system/memory/file/command)")
+	flags.StringVar(&c.CopyCommand, "copy-command", "", "Command receiving copied text on stdin for the command backend")
+	flags.StringVar(&c.PasteCommand, "paste-command", "", "Command printing the clipboard for the command backend")
+	flags.StringVar(&c.SecretFilter, "secret-filter", "warn", "Action on copied secrets (off/warn/redact/block). warn keeps them out of logs, history and watchers")
+	flags.StringVar(&c.SecretRules, "secret-rules", "", "JSON file mapping names to regular expressions of extra secrets")
+	flags.StringVar(&c.Transform, "transform", "", "Comma separated transforms of the copied or pasted text, e.g. strip-ansi,dedent")
+	flags.StringVar(&c.CopyTransform, "copy-transform", "", "Comma separated transforms of the text copied to the server")
+	flags.StringVar(&c.PasteTransform, "paste-transform", "", "Comma separated transforms of the text pasted from the server")
+	flags.StringVar(&c.Charset, "charset", "", "Character set of the copied and pasted text, or of the file and command server clipboards, e.g. utf-16le or shift_jis")
+	flags.StringVar(&c.BridgeChannels, "bridge-channels", "", "Comma separated channels shared with the system clipboard")
 	return flags
 }
 
@@ -80,12 +132,15 @@ func (c *CLI) parse(args []string, skip bool) error {
 		return err
 	}
 
-	if c.Type == PASTE || c.Type == SERVER {
+	if c.Type == PASTE || c.Type == SERVER || c.Type == WATCH {
 		return nil
 	}
 
+	var positional []string
+
 	for 0 < flags.NArg() {
 		arg = flags.Arg(0)
+		positional = append(positional, arg)
 		err := flags.Parse(flags.Args()[1:])
 		if err != nil {
 			return err
@@ -97,6 +152,23 @@ func (c *CLI) parse(args []string, skip bool) error {
 		return nil
 	}
 
+	// subcommands taking several arguments don't read stdin
+	if c.Type == HISTORY || c.Type == QUEUE || c.Type == GET {
+		c.Args = positional
+		return nil
+	}
+
+	// except `snippet save NAME` which reads the snippet like copy
+	if c.Type == SNIPPET {
+		c.Args = positional
+
+		if len(positional) != 2 || positional[0] != "save" {
+			return nil
+		}
+
+		arg = ""
+	}
+
 	if arg != "" {
 		c.DataSource = arg
 	} else {
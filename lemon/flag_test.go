@@ -4,6 +4,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestCLIParse(t *testing.T) {
@@ -21,72 +22,400 @@ func TestCLIParse(t *testing.T) {
 	defaultHost := "localhost"
 	defaultAllow := "0.0.0.0/0,::/0"
 	defaultLogLevel := 1
+	defaultClipboardBackend := "system"
+	defaultHistorySize := 100
+	defaultFormat := "text"
+	defaultWatchInterval := 500 * time.Millisecond
+	defaultSelection := "clipboard"
+	defaultSecretFilter := "warn"
+	defaultPasteFrom := "first"
+	defaultOnCollision := "rename"
+	defaultMaxFileSize := int64(1 << 30)
+	defaultUploadTTL := 24 * time.Hour
 
 	assert([]string{"pbpaste", "--port", "1124"}, CLI{
-		Type:     PASTE,
-		Host:     defaultHost,
-		Port:     1124,
-		Allow:    defaultAllow,
-		LogLevel: defaultLogLevel,
+		Type:             PASTE,
+		Host:             defaultHost,
+		Port:             1124,
+		Allow:            defaultAllow,
+		LogLevel:         defaultLogLevel,
+		ClipboardBackend: defaultClipboardBackend,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
 	})
 
 	assert([]string{"/usr/bin/pbpaste", "--port", "1124"}, CLI{
-		Type:     PASTE,
-		Host:     defaultHost,
-		Port:     1124,
-		Allow:    defaultAllow,
-		LogLevel: defaultLogLevel,
+		Type:             PASTE,
+		Host:             defaultHost,
+		Port:             1124,
+		Allow:            defaultAllow,
+		LogLevel:         defaultLogLevel,
+		ClipboardBackend: defaultClipboardBackend,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
 	})
 
 	assert([]string{"vimonade", "paste"}, CLI{
-		Type:     PASTE,
-		Host:     defaultHost,
-		Port:     defaultPort,
-		Allow:    defaultAllow,
-		LogLevel: defaultLogLevel,
+		Type:             PASTE,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		LogLevel:         defaultLogLevel,
+		ClipboardBackend: defaultClipboardBackend,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
 	})
 
 	assert([]string{"pbcopy", "hogefuga"}, CLI{
-		Type:       COPY,
-		Host:       defaultHost,
-		Port:       defaultPort,
-		Allow:      defaultAllow,
-		DataSource: "hogefuga",
-		LogLevel:   defaultLogLevel,
+		Type:             COPY,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		DataSource:       "hogefuga",
+		LogLevel:         defaultLogLevel,
+		ClipboardBackend: defaultClipboardBackend,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
 	})
 
 	assert([]string{"/usr/bin/pbcopy", "hogefuga"}, CLI{
-		Type:       COPY,
-		Host:       defaultHost,
-		Port:       defaultPort,
-		Allow:      defaultAllow,
-		DataSource: "hogefuga",
-		LogLevel:   defaultLogLevel,
+		Type:             COPY,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		DataSource:       "hogefuga",
+		LogLevel:         defaultLogLevel,
+		ClipboardBackend: defaultClipboardBackend,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
 	})
 
 	assert([]string{"vimonade", "copy", "hogefuga"}, CLI{
-		Type:       COPY,
-		Host:       defaultHost,
-		Port:       defaultPort,
-		Allow:      defaultAllow,
-		DataSource: "hogefuga",
-		LogLevel:   defaultLogLevel,
+		Type:             COPY,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		DataSource:       "hogefuga",
+		LogLevel:         defaultLogLevel,
+		ClipboardBackend: defaultClipboardBackend,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+	})
+
+	assert([]string{"vimonade", "copy", "--register", "a", "hogefuga"}, CLI{
+		Type:             COPY,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		DataSource:       "hogefuga",
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+		Register:         "a",
+		ClipboardBackend: defaultClipboardBackend,
+	})
+
+	assert([]string{"vimonade", "copy", "--trim", "--skip-blank", "hogefuga"}, CLI{
+		Type:             COPY,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		DataSource:       "hogefuga",
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+		Trim:             true,
+		SkipBlank:        true,
+		ClipboardBackend: defaultClipboardBackend,
+	})
+
+	assert([]string{"vimonade", "copy", "--sensitive", "--ttl", "10s", "hogefuga"}, CLI{
+		Type:             COPY,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		DataSource:       "hogefuga",
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+		Sensitive:        true,
+		TTL:              10 * time.Second,
+		ClipboardBackend: defaultClipboardBackend,
+	})
+
+	assert([]string{"vimonade", "paste", "--transform", "strip-ansi,dedent"}, CLI{
+		Type:             PASTE,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+		Transform:        "strip-ansi,dedent",
+		ClipboardBackend: defaultClipboardBackend,
+		SecretFilter:     defaultSecretFilter,
+	})
+
+	assert([]string{"vimonade", "watch", "--channel", "pair"}, CLI{
+		Type:             WATCH,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+		Channel:          "pair",
+		ClipboardBackend: defaultClipboardBackend,
+		SecretFilter:     defaultSecretFilter,
+	})
+
+	assert([]string{"vimonade", "paste", "--register", "a"}, CLI{
+		Type:             PASTE,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+		Register:         "a",
+		ClipboardBackend: defaultClipboardBackend,
+	})
+
+	assert([]string{"vimonade", "paste", "--index", "3"}, CLI{
+		Type:             PASTE,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		LogLevel:         defaultLogLevel,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+		Index:            3,
+		HistorySize:      defaultHistorySize,
+		ClipboardBackend: defaultClipboardBackend,
+	})
+
+	assert([]string{"vimonade", "history", "get", "3"}, CLI{
+		Type:             HISTORY,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		Args:             []string{"get", "3"},
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		ClipboardBackend: defaultClipboardBackend,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+	})
+
+	assert([]string{"vimonade", "get", "report.pdf", "/tmp", "--force"}, CLI{
+		Type:             GET,
+		Force:            true,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		Args:             []string{"report.pdf", "/tmp"},
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		ClipboardBackend: defaultClipboardBackend,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+	})
+
+	assert([]string{"vimonade", "snippet", "save", "trailer", "Signed-off-by: hoge"}, CLI{
+		Type:             SNIPPET,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		Args:             []string{"save", "trailer", "Signed-off-by: hoge"},
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		ClipboardBackend: defaultClipboardBackend,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+	})
+
+	assert([]string{"vimonade", "queue", "--collapse-queue", "flush"}, CLI{
+		Type:             QUEUE,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		Args:             []string{"flush"},
+		CollapseQueue:    true,
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		ClipboardBackend: defaultClipboardBackend,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
 	})
 
 	assert([]string{"vimonade", "send", "hogefuga.txt"}, CLI{
-		Type:       SEND,
-		Host:       defaultHost,
-		Port:       defaultPort,
-		Allow:      defaultAllow,
-		DataSource: "hogefuga.txt",
-		LogLevel:   defaultLogLevel,
-	})
-
-	assert([]string{"vimonade", "--allow", "192.168.0.0/24", "server", "--port", "1124"}, CLI{
-		Type:     SERVER,
-		Host:     defaultHost,
-		Port:     1124,
-		Allow:    "192.168.0.0/24",
-		LogLevel: defaultLogLevel,
+		Type:             SEND,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		DataSource:       "hogefuga.txt",
+		LogLevel:         defaultLogLevel,
+		ClipboardBackend: defaultClipboardBackend,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+	})
+
+	assert([]string{"vimonade", "--allow", "192.168.0.0/24", "server", "--port", "1124", "--vimonade-dir", "/srv/drop", "--state-dir", "/var/lib/vimonade"}, CLI{
+		Type:             SERVER,
+		VimonadeDir:      "/srv/drop",
+		StateDir:         "/var/lib/vimonade",
+		Host:             defaultHost,
+		Port:             1124,
+		Allow:            "192.168.0.0/24",
+		LogLevel:         defaultLogLevel,
+		ClipboardBackend: defaultClipboardBackend,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+	})
+
+	assert([]string{"vimonade", "server", "--clipboard-backend", "command", "--copy-command", "wl-copy", "--paste-command", "wl-paste -n"}, CLI{
+		Type:             SERVER,
+		Host:             defaultHost,
+		Port:             defaultPort,
+		Allow:            defaultAllow,
+		LogLevel:         defaultLogLevel,
+		HistorySize:      defaultHistorySize,
+		Format:           defaultFormat,
+		WatchInterval:    defaultWatchInterval,
+		Selection:        defaultSelection,
+		SecretFilter:     defaultSecretFilter,
+		PasteFrom:        defaultPasteFrom,
+		OnCollision:      defaultOnCollision,
+		MaxFileSize:      defaultMaxFileSize,
+		UploadTTL:        defaultUploadTTL,
+		ClipboardBackend: "command",
+		CopyCommand:      "wl-copy",
+		PasteCommand:     "wl-paste -n",
 	})
 }
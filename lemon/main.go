@@ -12,19 +12,66 @@ Sub Commands:
   copy [text]                 Copy text.
   paste                       Paste text.
   send 						  Send file back to host vimonade server.
+  get NAME [DEST]             Download a file from the files dir of the server.
   server                      Start vimonade server.
+  history [list|get N|rm N|clear]
+                              Manage the copy history of the server.
+  watch                       Print clipboard changes as JSON lines.
+  snippet [ls|get NAME|save NAME [text]|rm NAME]
+                              Manage the named snippets of the server.
+  queue [ls|flush|clear]      Manage the copies queued while the server was unreachable.
 
 Options:
   --port=2489                 TCP port number
   --line-ending               Convert Line Ending (CR/CRLF)
   --allow="0.0.0.0/0,::/0"    Allow IP Range                [Server only]
-  --host="localhost"          Destination hostname          [Client only]
+  --host="localhost"          Destination hostname          [Client only] copy/paste take host[:port],...
+  --register                  Vim register (a-z, +, ...)    [copy/paste only]
+  --regtype                   Register type (v/V/b{width})  [copy only]
+  --selection=clipboard       clipboard/primary/secondary   [copy/paste only]
+  --channel                   Shared channel name           [copy/paste/watch only] isolated from the clipboard
+  --paste-from=first          Paste from first/newest host  [paste only] newest compares server clocks
+  --format=text               Paste as text or json         [paste only] json = [lines, regtype]
+  --index=0                   Paste Nth history entry       [paste only]
+  --type                      MIME type, e.g. image/png     [copy/paste only]
+  --transform                 Text transforms, e.g. dedent  [copy/paste only] see Transforms
+  --trim                      Trim surrounding white space  [copy only]
+  --charset=utf-8             Text charset, e.g. shift_jis  [Client: stdin/stdout, Server: file/command clipboard]
+  --skip-blank                Don't copy blank text         [copy only]
+  --sensitive                 Expire, keep out of history   [copy only] expires after 30s without --ttl
+  --ttl                       Expire after duration (30s)   [copy only]
+  --collapse-queue            Flush only the latest copies  [copy/paste/queue only] one per register
+  --on-collision=rename       Sent file name collisions     [Server only] rename/overwrite/reject
+  --max-file-size=1073741824  Sent file size limit (bytes)  [Server only] 0 = no limit
+  --upload-ttl=24h            Interrupted upload lifetime   [Server only] 0 = forever
+  --state-dir=~/.vimonade     Server state directory        [Server only] registers, history, snippets
+  --history-size=100          Copy history size             [Server only]
+  --watch-interval=500ms      Host clipboard check interval [Server only]
+  --digest                    Print digests, not values     [watch only]
+  --force                     Overwrite the destination     [get only] refuses an existing file without it
   --no-fallback-messages      Do not show fallback messages [Client only]
   --trans-loopback=true       Translate loopback address    [open subcommand only]
   --trans-localfile=true      Translate local file path     [open subcommand only]
   --log-level=1               Log level                     [4 = Critical, 0 = Debug]
+  --clipboard-backend=system  Clipboard backend             [Server only] system/memory/file/command
+  --copy-command              Copy command of backend       [Server only] e.g. "wl-copy"
+  --paste-command             Paste command of backend      [Server only] e.g. "wl-paste -n"
+  --secret-filter=warn        Action on copied secrets      [Server only] off/warn/redact/block, warn = sensitive
+  --secret-rules              Extra secret rules file       [Server only] {"name": "regexp"}
+  --copy-transform            Transforms of copied text     [Server only] see Transforms
+  --paste-transform           Transforms of pasted text     [Server only] see Transforms
+  --bridge-channels           Channels bridged to clipboard [Server only] e.g. pair,review
   --help                      Show this message
 
+Transforms:
+  lf, crlf                    Convert line endings
+  trim-trailing               Trim white space at the end of lines
+  dedent                      Remove the common indentation
+  strip-ansi                  Remove ANSI escape sequences
+  nfc, nfd                    Normalize Unicode
+  expand-tabs[:8]             Expand tabs to spaces
+  json-pretty                 Indent JSON
+
 
 Version:
   %s`, Version)
@@ -43,3 +90,13 @@ func ConvertLineEnding(text, option string) string {
 		return text
 	}
 }
+
+// IsClipboardRegister reports whether a Vim register is backed by the system clipboard
+func IsClipboardRegister(register string) bool {
+	switch register {
+	case "", `"`, "+":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,24 @@
+package lemon
+
+import (
+	"mime"
+	"strings"
+)
+
+// TextMimeType is the MIME type of plain text
+const TextMimeType = "text/plain"
+
+// MediaType returns mimeType without its parameters, e.g. text/plain for "text/plain; charset=utf-8"
+func MediaType(mimeType string) string {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(mimeType))
+	}
+
+	return mediaType
+}
+
+// IsText reports whether mimeType is plain text
+func IsText(mimeType string) bool {
+	return mimeType == "" || MediaType(mimeType) == TextMimeType
+}
@@ -0,0 +1,25 @@
+package lemon
+
+import "testing"
+
+func TestIsText(t *testing.T) {
+	for _, tc := range []struct {
+		mimeType string
+		expected bool
+	}{
+		{"", true},
+		{"text/plain", true},
+		{"Text/Plain; charset=utf-8", true},
+		{"text/plainer", false},
+		{"text/html", false},
+		{"image/png", false},
+	} {
+		if got := IsText(tc.mimeType); got != tc.expected {
+			t.Errorf("%q: Expected: %v, got %v", tc.mimeType, tc.expected, got)
+		}
+	}
+
+	if got := MediaType("image/PNG; q=1"); got != "image/png" {
+		t.Errorf("Expected: %q, got %q", "image/png", got)
+	}
+}
@@ -0,0 +1,21 @@
+package lemon
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	previewLength = 60
+)
+
+// Preview returns the first characters of text on a single line
+func Preview(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	if utf8.RuneCountInString(text) > previewLength {
+		return string([]rune(text)[:previewLength]) + "..."
+	}
+
+	return text
+}
@@ -0,0 +1,22 @@
+package lemon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPreview(t *testing.T) {
+	for _, tc := range []struct {
+		text     string
+		expected string
+	}{
+		{"", ""},
+		{"hoge", "hoge"},
+		{"  hoge\n\tfuga\n", "hoge fuga"},
+		{strings.Repeat("あ", 61), strings.Repeat("あ", 60) + "..."},
+	} {
+		if got := Preview(tc.text); got != tc.expected {
+			t.Errorf("Expected: %q, got %q", tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package lemon
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Vim register types
+const (
+	Charwise  = "v"
+	Linewise  = "V"
+	Blockwise = "b"
+)
+
+var regtypeRegexp = regexp.MustCompile("^(?:([vcVl])|([b\x16])([0-9]*))$")
+
+// NormalizeRegtype converts the register types accepted by setreg() to v, V or b{width}
+func NormalizeRegtype(regtype string) (string, error) {
+	if regtype == "" {
+		return "", nil
+	}
+
+	m := regtypeRegexp.FindStringSubmatch(regtype)
+	if m == nil {
+		return "", fmt.Errorf("invalid register type: %q", regtype)
+	}
+
+	switch m[1] {
+	case "v", "c":
+		return Charwise, nil
+	case "V", "l":
+		return Linewise, nil
+	default:
+		return Blockwise + m[3], nil
+	}
+}
+
+// InferRegtype guesses the register type of text the same way Neovim does:
+// text ending with a newline is linewise, anything else charwise.
+func InferRegtype(text string) string {
+	if strings.HasSuffix(text, "\n") {
+		return Linewise
+	}
+
+	return Charwise
+}
+
+// SplitLines splits text into the lines of a Vim register.
+// The trailing newline of a linewise register is implied and dropped.
+func SplitLines(text, regtype string) []string {
+	if regtype == Linewise {
+		text = strings.TrimSuffix(text, "\n")
+	}
+
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,45 @@
+package lemon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeRegtype(t *testing.T) {
+	assert := func(regtype, expected string) {
+		got, err := NormalizeRegtype(regtype)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != expected {
+			t.Errorf("Expected: %q, got %q", expected, got)
+		}
+	}
+
+	assert("", "")
+	assert("v", "v")
+	assert("c", "v")
+	assert("V", "V")
+	assert("l", "V")
+	assert("b", "b")
+	assert("b12", "b12")
+	assert("\x165", "b5")
+
+	if _, err := NormalizeRegtype("x"); err == nil {
+		t.Error("Expected an error for an unknown register type")
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	assert := func(text, regtype string, expected []string) {
+		if got := SplitLines(text, regtype); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Expected: %q, got %q", expected, got)
+		}
+	}
+
+	assert("aaa\nbbb\n", InferRegtype("aaa\nbbb\n"), []string{"aaa", "bbb"})
+	assert("aaa\nbbb", InferRegtype("aaa\nbbb"), []string{"aaa", "bbb"})
+	assert("aaa\nbbb\n", "v", []string{"aaa", "bbb", ""})
+	assert("ab\ncd", "b2", []string{"ab", "cd"})
+}
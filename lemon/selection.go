@@ -0,0 +1,25 @@
+package lemon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// X11 selections
+const (
+	SelectionClipboard = "clipboard"
+	SelectionPrimary   = "primary"
+	SelectionSecondary = "secondary"
+)
+
+// NormalizeSelection returns the X11 selection named by selection, the clipboard when empty
+func NormalizeSelection(selection string) (string, error) {
+	switch s := strings.ToLower(selection); s {
+	case "":
+		return SelectionClipboard, nil
+	case SelectionClipboard, SelectionPrimary, SelectionSecondary:
+		return s, nil
+	default:
+		return "", fmt.Errorf("invalid selection: %q", selection)
+	}
+}
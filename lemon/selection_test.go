@@ -0,0 +1,25 @@
+package lemon
+
+import "testing"
+
+func TestNormalizeSelection(t *testing.T) {
+	assert := func(selection, expected string) {
+		got, err := NormalizeSelection(selection)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != expected {
+			t.Errorf("Expected: %q, got %q", expected, got)
+		}
+	}
+
+	assert("", "clipboard")
+	assert("clipboard", "clipboard")
+	assert("PRIMARY", "primary")
+	assert("secondary", "secondary")
+
+	if _, err := NormalizeSelection("x"); err == nil {
+		t.Error("Expected an error for an unknown selection")
+	}
+}
@@ -0,0 +1,224 @@
+package lemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const defaultTabWidth = 8
+
+var ansiRegexp = regexp.MustCompile("\x1b\\[[0-?]*[ -/]*[@-~]|\x1b\\][^\x07\x1b]*(?:\x07|\x1b\\\\)|\x1b[@-Z\\\\-_]")
+
+// transformStep is a single step of a Pipeline
+type transformStep struct {
+	name      string
+	transform func(string) (string, error)
+}
+
+// Pipeline is a list of text transforms applied in order
+type Pipeline []transformStep
+
+// ParsePipeline returns the Pipeline described by a comma separated list of steps, e.g. "strip-ansi,dedent".
+// Steps are lf, crlf, trim-trailing, dedent, strip-ansi, nfc, nfd, expand-tabs[:width] and json-pretty.
+func ParsePipeline(spec string) (Pipeline, error) {
+	var pipeline Pipeline
+
+	for _, step := range strings.Split(spec, ",") {
+		step = strings.TrimSpace(step)
+		if step == "" {
+			continue
+		}
+
+		name, arg := step, ""
+		if i := strings.Index(step, ":"); i >= 0 {
+			name, arg = step[:i], step[i+1:]
+		}
+
+		transform, err := newTransform(name, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		pipeline = append(pipeline, transformStep{name: step, transform: transform})
+	}
+
+	return pipeline, nil
+}
+
+func newTransform(name, arg string) (func(string) (string, error), error) {
+	if arg != "" && name != "expand-tabs" {
+		return nil, fmt.Errorf("transform %s takes no argument", name)
+	}
+
+	switch name {
+	case "lf", "crlf":
+		return func(text string) (string, error) { return ConvertLineEnding(text, name), nil }, nil
+	case "trim-trailing":
+		return infallible(TrimTrailingSpace), nil
+	case "dedent":
+		return infallible(Dedent), nil
+	case "strip-ansi":
+		return infallible(StripANSI), nil
+	case "nfc":
+		return infallible(norm.NFC.String), nil
+	case "nfd":
+		return infallible(norm.NFD.String), nil
+	case "expand-tabs":
+		width := defaultTabWidth
+
+		if arg != "" {
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid tab width: %s", arg)
+			}
+
+			width = n
+		}
+
+		return infallible(func(text string) string { return ExpandTabs(text, width) }), nil
+	case "json-pretty":
+		return PrettyJSON, nil
+	default:
+		return nil, fmt.Errorf("unknown transform: %s", name)
+	}
+}
+
+func infallible(transform func(string) string) func(string) (string, error) {
+	return func(text string) (string, error) { return transform(text), nil }
+}
+
+// Apply runs text through every step of the pipeline
+func (p Pipeline) Apply(text string) (string, error) {
+	for _, step := range p {
+		var err error
+
+		text, err = step.transform(text)
+		if err != nil {
+			return "", fmt.Errorf("transform %s failed: %s", step.name, err)
+		}
+	}
+
+	return text, nil
+}
+
+// String returns the steps of the pipeline as parsed by ParsePipeline
+func (p Pipeline) String() string {
+	names := make([]string, len(p))
+	for i, step := range p {
+		names[i] = step.name
+	}
+
+	return strings.Join(names, ",")
+}
+
+// TrimTrailingSpace removes the spaces and tabs at the end of every line
+func TrimTrailingSpace(text string) string {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		cr := strings.HasSuffix(line, "\r")
+		line = strings.TrimRight(strings.TrimSuffix(line, "\r"), " \t")
+
+		if cr {
+			line += "\r"
+		}
+
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Dedent removes the indentation common to every non-blank line
+func Dedent(text string) string {
+	lines := strings.Split(text, "\n")
+
+	var (
+		prefix string
+		found  bool
+	)
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+
+		if !found {
+			prefix, found = indent, true
+			continue
+		}
+
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	if prefix == "" {
+		return text
+	}
+
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, prefix)
+		if strings.TrimSpace(lines[i]) == "" {
+			lines[i] = strings.TrimLeft(lines[i], " \t")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// StripANSI removes ANSI escape sequences like colors
+func StripANSI(text string) string {
+	return ansiRegexp.ReplaceAllString(text, "")
+}
+
+// ExpandTabs replaces tabs by spaces up to the next multiple of width
+func ExpandTabs(text string, width int) string {
+	if !strings.Contains(text, "\t") {
+		return text
+	}
+
+	var b strings.Builder
+
+	column := 0
+
+	for _, r := range text {
+		switch r {
+		case '\t':
+			n := width - column%width
+			b.WriteString(strings.Repeat(" ", n))
+			column += n
+		case '\n', '\r':
+			b.WriteRune(r)
+			column = 0
+		default:
+			b.WriteRune(r)
+			column++
+		}
+	}
+
+	return b.String()
+}
+
+// PrettyJSON indents a JSON document with two spaces, keeping a trailing newline
+func PrettyJSON(text string) (string, error) {
+	var b bytes.Buffer
+
+	if err := json.Indent(&b, []byte(strings.TrimSpace(text)), "", "  "); err != nil {
+		return "", err
+	}
+
+	if strings.HasSuffix(text, "\n") {
+		b.WriteByte('\n')
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,50 @@
+package lemon
+
+import "testing"
+
+func TestPipeline(t *testing.T) {
+	assert := func(spec, text, expected string) {
+		pipeline, err := ParsePipeline(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := pipeline.Apply(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != expected {
+			t.Errorf("%s: Expected: %q, got %q", spec, expected, got)
+		}
+	}
+
+	assert("", "a \n", "a \n")
+	assert("crlf", "a\nb", "a\r\nb")
+	assert("lf,trim-trailing", "a \t\r\nb  \n", "a\nb\n")
+	assert("trim-trailing", "a \r\n", "a\r\n")
+	assert("dedent", "    if a:\n\n      b\n    c\n", "if a:\n\n  b\nc\n")
+	assert("dedent", "\ta\n  b", "\ta\n  b")
+	assert("strip-ansi", "\x1b[1;31merror\x1b[0m: \x1b]0;title\x07x", "error: x")
+	assert("nfc", "e\u0301", "\u00e9")
+	assert("nfd", "\u00e9", "e\u0301")
+	assert("expand-tabs", "\ta\tb", "        a       b")
+	assert("expand-tabs:4", "ab\tc\n\td", "ab  c\n    d")
+	assert("json-pretty", `{"a":[1,2]}`+"\n", "{\n  \"a\": [\n    1,\n    2\n  ]\n}\n")
+	assert(" strip-ansi , dedent ", "  \x1b[32mok\x1b[0m\n  ok", "ok\nok")
+
+	for _, spec := range []string{"unknown", "dedent:2", "expand-tabs:0"} {
+		if _, err := ParsePipeline(spec); err == nil {
+			t.Errorf("%s: Expected an error", spec)
+		}
+	}
+
+	pipeline, err := ParsePipeline("json-pretty")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pipeline.Apply("not json"); err == nil {
+		t.Error("Expected an error for invalid json")
+	}
+}
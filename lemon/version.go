@@ -0,0 +1,20 @@
+package lemon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ContentVersion returns the version of pasted content, a hash of its register type, MIME type and data.
+// The MIME type is empty for text pasted as a value.
+func ContentVersion(regtype, mimeType string, data []byte) string {
+	h := sha256.New()
+
+	h.Write([]byte(regtype))
+	h.Write([]byte{0})
+	h.Write([]byte(mimeType))
+	h.Write([]byte{0})
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
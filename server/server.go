@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pocke/go-iprange"
 	"go.uber.org/zap"
@@ -21,9 +23,9 @@ import (
 
 func Serve(c *lemon.CLI, creds credentials.TransportCredentials, logger *zap.Logger) int {
 	// create vimonade dir if !exist
-	var vimonadeDir string
+	var vimonadeDir, stateDir string
 
-	if c.VimonadeDir == "" {
+	if c.VimonadeDir == "" || c.StateDir == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
 			logger.Error("Cannot find $HOME error: " + err.Error())
@@ -31,10 +33,18 @@ func Serve(c *lemon.CLI, creds credentials.TransportCredentials, logger *zap.Log
 		}
 
 		vimonadeDir = home + "/.vimonade/files"
-	} else {
+		stateDir = home + "/.vimonade"
+	}
+
+	if c.VimonadeDir != "" {
 		vimonadeDir = c.VimonadeDir
 	}
 
+	// state shared across restarts never goes next to a user supplied files dir
+	if c.StateDir != "" {
+		stateDir = c.StateDir
+	}
+
 	logger.Debug("current vimonade dir: " + vimonadeDir)
 
 	if _, err := os.Stat(vimonadeDir); os.IsNotExist(err) {
@@ -45,11 +55,109 @@ func Serve(c *lemon.CLI, creds credentials.TransportCredentials, logger *zap.Log
 		}
 	}
 
+	logger.Debug("current state dir: " + stateDir)
+
+	if err := os.MkdirAll(stateDir, 0700); err != nil {
+		logger.Error("Creating state dir error: " + err.Error())
+		return lemon.RPCError
+	}
+
 	// Server
-	store := service.NewDiskFileStore(vimonadeDir)
+	store, err := service.NewDiskFileStore(vimonadeDir, service.FileStoreConfig{
+		Collision: c.OnCollision,
+		MaxSize:   c.MaxFileSize,
+		UploadTTL: c.UploadTTL,
+	})
+	if err != nil {
+		logger.Error("Creating file store error: " + err.Error())
+		return lemon.FlagParseError
+	}
+
+	// uploads interrupted for longer than the ttl are removed even if nothing is sent anymore
+	go func() {
+		for range time.Tick(time.Minute) {
+			store.ExpireUploads()
+		}
+	}()
+
+	registers, err := service.NewDiskRegisterStore(filepath.Join(stateDir, "registers.json"))
+	if err != nil {
+		logger.Error("Loading registers error: " + err.Error())
+		return lemon.RPCError
+	}
+
+	history, err := service.NewDiskHistoryStore(filepath.Join(stateDir, "history.json"), c.HistorySize)
+	if err != nil {
+		logger.Error("Loading history error: " + err.Error())
+		return lemon.RPCError
+	}
+
+	snippets, err := service.NewDiskSnippetStore(filepath.Join(stateDir, "snippets.json"))
+	if err != nil {
+		logger.Error("Loading snippets error: " + err.Error())
+		return lemon.RPCError
+	}
+
+	cb, err := service.NewClipboard(service.ClipboardConfig{
+		Backend:      c.ClipboardBackend,
+		FilePath:     filepath.Join(stateDir, "clipboard"),
+		CopyCommand:  c.CopyCommand,
+		PasteCommand: c.PasteCommand,
+		Charset:      c.Charset,
+	}, logger)
+	if err != nil {
+		logger.Error("Creating clipboard error: " + err.Error())
+		return lemon.RPCError
+	}
+
+	watcher := service.NewWatcher(cb, c.WatchInterval, logger)
+	// host clipboard changes are dated for --paste-from newest
+	watcher.Start()
 
-	if err := runServer(context.Background(),
-		service.NewVimonadeServerService(store, c.LineEnding, logger),
+	rules, err := service.LoadSecretRules(c.SecretRules)
+	if err != nil {
+		logger.Error("Loading secret rules error: " + err.Error())
+		return lemon.RPCError
+	}
+
+	secrets, err := service.NewSecretFilter(c.SecretFilter, rules)
+	if err != nil {
+		logger.Error("Creating secret filter error: " + err.Error())
+		return lemon.RPCError
+	}
+
+	copyTransform, err := lemon.ParsePipeline(c.CopyTransform)
+	if err != nil {
+		logger.Error("Parsing copy transform error: " + err.Error())
+		return lemon.FlagParseError
+	}
+
+	pasteTransform, err := lemon.ParsePipeline(c.PasteTransform)
+	if err != nil {
+		logger.Error("Parsing paste transform error: " + err.Error())
+		return lemon.FlagParseError
+	}
+
+	bridged, err := lemon.ParseChannels(c.BridgeChannels)
+	if err != nil {
+		logger.Error("Parsing bridged channels error: " + err.Error())
+		return lemon.FlagParseError
+	}
+
+	config := service.ServiceConfig{
+		FileStore:  store,
+		Clipboard:  cb,
+		Registers:  registers,
+		History:    history,
+		Snippets:   snippets,
+		Watcher:    watcher,
+		Channels:   service.NewChannels(bridged),
+		Secrets:    secrets,
+		Transforms: &service.Transforms{Copy: copyTransform, Paste: pasteTransform},
+		LineEnding: c.LineEnding,
+	}
+
+	if err := runServer(context.Background(), service.NewVimonadeServerService(config, logger),
 		logger, creds, c.Allow, fmt.Sprintf("%s:%d", c.Host, c.Port)); err != nil {
 		logger.Error("Server error: " + err.Error())
 
@@ -74,11 +182,11 @@ func runServer(ctx context.Context, srv pb.VimonadeServiceServer, logger *zap.Lo
 	// register service
 	var server *grpc.Server
 
-	ipInterceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	checkIP := func(ctx context.Context) error {
 		p, ok := peer.FromContext(ctx)
 		if !ok {
 			logger.Error("error fetching ip addr from request")
-			return nil, fmt.Errorf("error fetching ip addr from request")
+			return fmt.Errorf("error fetching ip addr from request")
 		}
 
 		ipAndPort := p.Addr.String()
@@ -86,19 +194,36 @@ func runServer(ctx context.Context, srv pb.VimonadeServiceServer, logger *zap.Lo
 
 		if !ra.IncludeStr(ip[0]) {
 			logger.Error(fmt.Sprintf("not in allow ip range: %s | %s", ip[0], ra))
-			return nil, fmt.Errorf("not in allow ip range: %s", ip[0])
+			return fmt.Errorf("not in allow ip range: %s", ip[0])
+		}
+
+		return nil
+	}
+
+	ipInterceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkIP(ctx); err != nil {
+			return nil, err
 		}
 
 		// Calls the handler
 		return handler(ctx, req)
 	}
 
+	// streaming RPCs like Send and Watch are subject to the same ip range
+	ipStreamInterceptor := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkIP(ss.Context()); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+
 	if creds == nil {
 		// insecure
-		server = grpc.NewServer(grpc.UnaryInterceptor(ipInterceptor))
+		server = grpc.NewServer(grpc.UnaryInterceptor(ipInterceptor), grpc.StreamInterceptor(ipStreamInterceptor))
 	} else {
 		// secure
-		server = grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(ipInterceptor))
+		server = grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(ipInterceptor), grpc.StreamInterceptor(ipStreamInterceptor))
 	}
 
 	pb.RegisterVimonadeServiceServer(server, srv)
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+var (
+	// ErrChannelClipboard is returned when a channel is asked for anything but the text of the clipboard register
+	ErrChannelClipboard = errors.New("channels only hold the text of the clipboard register")
+	// ErrChannelTTL is returned when an expiring copy is made to a channel
+	ErrChannelTTL = errors.New("channel copies cannot expire")
+	// ErrChannelLimit is returned when a channel is copied to while every channel held has members
+	ErrChannelLimit = errors.New("too many channels")
+	// ErrChannelSize is returned when a copy to a channel is too large
+	ErrChannelSize = errors.New("channel copy is too large")
+)
+
+const (
+	// maxChannels is the number of channels held in memory
+	maxChannels = 64
+	// maxChannelSize is the largest copy held by a channel
+	maxChannelSize = 1 << 20
+)
+
+// Channels holds the clipboards shared by the clients joining a channel.
+// A channel is isolated from the system clipboard, unless it's bridged to it.
+type Channels struct {
+	mutex     sync.Mutex
+	bridged   map[string]bool
+	registers map[string]*Register
+	members   map[string]int
+}
+
+// NewChannels returns channels, bridging the given ones to the system clipboard
+func NewChannels(bridged []string) *Channels {
+	c := &Channels{
+		bridged:   make(map[string]bool),
+		registers: make(map[string]*Register),
+		members:   make(map[string]int),
+	}
+
+	for _, name := range bridged {
+		c.bridged[name] = true
+	}
+
+	return c
+}
+
+// Resolve returns the normalized name of a channel, or "" if it's the system clipboard
+func (c *Channels) Resolve(name string) (string, error) {
+	name, err := lemon.NormalizeChannel(name)
+	if err != nil {
+		return "", err
+	}
+
+	if c.bridged[name] {
+		return "", nil
+	}
+
+	return name, nil
+}
+
+// Write replaces the content of a channel.
+// An empty copy drops a channel without members, and a new channel drops the oldest
+// channel without members when too many channels are held.
+func (c *Channels) Write(name string, register *Register) error {
+	if len(register.Value) > maxChannelSize {
+		return ErrChannelSize
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if register.Value == "" && c.members[name] == 0 {
+		delete(c.registers, name)
+		return nil
+	}
+
+	if _, ok := c.registers[name]; !ok && len(c.registers) >= maxChannels {
+		oldest := ""
+
+		for held, r := range c.registers {
+			if c.members[held] == 0 && (oldest == "" || r.CopiedAt.Before(c.registers[oldest].CopiedAt)) {
+				oldest = held
+			}
+		}
+
+		if oldest == "" {
+			return ErrChannelLimit
+		}
+
+		delete(c.registers, oldest)
+	}
+
+	c.registers[name] = register
+
+	return nil
+}
+
+// Read returns the content of a channel, or an empty register if nothing was copied to it
+func (c *Channels) Read(name string) *Register {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if register, ok := c.registers[name]; ok {
+		return register
+	}
+
+	return &Register{}
+}
+
+// Join adds a member to a channel and returns its number of members
+func (c *Channels) Join(name string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.members[name]++
+
+	return c.members[name]
+}
+
+// Leave removes a member from a channel and returns its number of members
+func (c *Channels) Leave(name string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.members[name]--
+
+	members := c.members[name]
+	if members <= 0 {
+		delete(c.members, name)
+	}
+
+	return members
+}
+
+// writeChannel replaces the content of a channel with a copy to its clipboard register
+func (s *vimonadeServiceServer) writeChannel(channel, name, selection string, register *Register, payloads []*Payload, ttl time.Duration) error {
+	if _, other := splitPayloads(payloads); len(other) > 0 || !lemon.IsClipboardRegister(name) || selection != lemon.SelectionClipboard {
+		return ErrChannelClipboard
+	}
+
+	if ttl > 0 {
+		return ErrChannelTTL
+	}
+
+	return s.channels.Write(channel, register)
+}
+
+// pasteChannel returns the content of a channel
+func (s *vimonadeServiceServer) pasteChannel(channel string, message *pb.PasteRequest) (*pb.PasteResponse, error) {
+	selection, err := lemon.NormalizeSelection(message.GetSelection())
+	if err != nil {
+		return &pb.PasteResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if message.GetIndex() > 0 || !lemon.IsText(message.GetMimeType()) || !lemon.IsClipboardRegister(message.GetRegister()) || selection != lemon.SelectionClipboard {
+		return &pb.PasteResponse{}, clipboardError(ErrChannelClipboard)
+	}
+
+	register := s.channels.Read(channel)
+
+	text, err := s.transforms.paste(register.Value)
+	if err != nil {
+		return &pb.PasteResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res := toPasteResponse(text, withRegtype(text, register.Regtype))
+	res.Sensitive = register.Sensitive
+	res.CopiedAt = unixMillis(register.CopiedAt)
+
+	return res, nil
+}
@@ -0,0 +1,63 @@
+package service_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrc2139/vimonade/service"
+)
+
+func TestChannelLimits(t *testing.T) {
+	channels := service.NewChannels(nil)
+	now := time.Now()
+
+	if err := channels.Write("large", &service.Register{Value: strings.Repeat("a", 1<<20+1)}); err != service.ErrChannelSize {
+		t.Errorf("Expected ErrChannelSize, got %v", err)
+	}
+
+	channels.Join("joined")
+
+	if err := channels.Write("joined", &service.Register{Value: "joined", CopiedAt: now.Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 63; i++ {
+		if err := channels.Write(fmt.Sprintf("c%d", i), &service.Register{Value: "hoge", CopiedAt: now.Add(time.Duration(i) * time.Second)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the oldest channel without members makes room for a new one
+	if err := channels.Write("new", &service.Register{Value: "new", CopiedAt: now.Add(time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if value := channels.Read("c0").Value; value != "" {
+		t.Errorf("Expected the oldest channel to be dropped, got %q", value)
+	}
+
+	if value := channels.Read("joined").Value; value != "joined" {
+		t.Errorf("Expected a channel with members to be kept, got %q", value)
+	}
+
+	// an empty copy drops a channel without members
+	if err := channels.Write("new", &service.Register{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := channels.Write("other", &service.Register{Value: "other"}); err != nil {
+		t.Errorf("Expected the dropped channel to make room, got %v", err)
+	}
+
+	for i := 1; i < 63; i++ {
+		channels.Join(fmt.Sprintf("c%d", i))
+	}
+
+	channels.Join("other")
+
+	if err := channels.Write("full", &service.Register{Value: "full"}); err != service.ErrChannelLimit {
+		t.Errorf("Expected ErrChannelLimit when every channel has members, got %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/atotto/clipboard"
+	"go.uber.org/zap"
+
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// Clipboard backend names
+const (
+	SystemBackend  = "system"
+	MemoryBackend  = "memory"
+	FileBackend    = "file"
+	CommandBackend = "command"
+)
+
+// Clipboard is an interface to read and write the server clipboard
+type Clipboard interface {
+	// Read returns the current clipboard content
+	Read() (string, error)
+	// Write replaces the clipboard content
+	Write(text string) error
+}
+
+// ClipboardConfig contains the options used to build a Clipboard
+type ClipboardConfig struct {
+	Backend      string
+	FilePath     string
+	CopyCommand  string
+	PasteCommand string
+	// Charset is the character set of the clipboard text, UTF-8 when empty.
+	// Only the file and command backends hold encoded text.
+	Charset string
+}
+
+// NewClipboard returns the Clipboard backend described by config.
+// The system backend falls back to the file backend when no display is available.
+func NewClipboard(config ClipboardConfig, logger *zap.Logger) (Clipboard, error) {
+	charset, err := lemon.LookupCharset(config.Charset)
+	if err != nil {
+		return nil, err
+	}
+
+	// the system clipboard APIs and the memory backend take UTF-8 strings,
+	// and would hold the encoded bytes as mojibake
+	if charset != nil && config.Backend != FileBackend && config.Backend != CommandBackend {
+		backend := config.Backend
+		if backend == "" {
+			backend = SystemBackend
+		}
+
+		return nil, fmt.Errorf("charset %s is only supported by the file and command clipboard backends, not %s", config.Charset, backend)
+	}
+
+	cb, err := newBackend(config, logger)
+	if err != nil || charset == nil {
+		return cb, err
+	}
+
+	return NewCharsetClipboard(cb, charset), nil
+}
+
+func newBackend(config ClipboardConfig, logger *zap.Logger) (Clipboard, error) {
+	switch config.Backend {
+	case SystemBackend, "":
+		if hasDisplay() {
+			return NewSystemClipboard(), nil
+		}
+
+		logger.Warn("no display available, falling back to the file clipboard: " + config.FilePath)
+
+		return NewFileClipboard(config.FilePath), nil
+	case MemoryBackend:
+		return NewMemoryClipboard(), nil
+	case FileBackend:
+		return NewFileClipboard(config.FilePath), nil
+	case CommandBackend:
+		return NewCommandClipboard(config.CopyCommand, config.PasteCommand)
+	default:
+		return nil, fmt.Errorf("unknown clipboard backend: %s", config.Backend)
+	}
+}
+
+// hasDisplay reports whether the system clipboard can be reached
+func hasDisplay() bool {
+	if clipboard.Unsupported {
+		return false
+	}
+
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return true
+	default:
+		return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+	}
+}
+
+// SystemClipboard uses the clipboard of the host desktop
+type SystemClipboard struct{}
+
+// NewSystemClipboard returns a new SystemClipboard
+func NewSystemClipboard() *SystemClipboard {
+	return &SystemClipboard{}
+}
+
+// Read returns the content of the system clipboard
+func (*SystemClipboard) Read() (string, error) {
+	return clipboard.ReadAll()
+}
+
+// Write replaces the content of the system clipboard
+func (*SystemClipboard) Write(text string) error {
+	return clipboard.WriteAll(text)
+}
+
+// MemoryClipboard keeps the clipboard in memory
+type MemoryClipboard struct {
+	mutex      sync.RWMutex
+	text       string
+	formats    map[string][]byte
+	selections map[string]string
+}
+
+// NewMemoryClipboard returns a new MemoryClipboard
+func NewMemoryClipboard() *MemoryClipboard {
+	return &MemoryClipboard{}
+}
+
+// Read returns the stored text
+func (c *MemoryClipboard) Read() (string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.text, nil
+}
+
+// Write replaces the stored text
+func (c *MemoryClipboard) Write(text string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.text = text
+	c.formats = nil
+
+	return nil
+}
+
+// FileClipboard keeps the clipboard in a plain file
+type FileClipboard struct {
+	mutex sync.RWMutex
+	path  string
+}
+
+// NewFileClipboard returns a new FileClipboard writing to path
+func NewFileClipboard(path string) *FileClipboard {
+	return &FileClipboard{path: path}
+}
+
+// Read returns the content of the file, or an empty string if it doesn't exist yet
+func (c *FileClipboard) Read() (string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	b, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot read clipboard file: %s", err)
+	}
+
+	return string(b), nil
+}
+
+// Write replaces the content of the file
+func (c *FileClipboard) Write(text string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.removeFormats(); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(c.path, []byte(text), 0600); err != nil {
+		return fmt.Errorf("cannot write clipboard file: %s", err)
+	}
+
+	return nil
+}
+
+// CommandClipboard pipes the clipboard through external commands, e.g. wl-copy/wl-paste.
+// A {type} argument is replaced by the MIME type of the content, e.g. "wl-copy --type {type}",
+// and a {selection} argument by the X11 selection, e.g. "xclip -selection {selection} -i".
+type CommandClipboard struct {
+	copyArgs  []string
+	pasteArgs []string
+}
+
+// NewCommandClipboard returns a new CommandClipboard.
+// copyCommand receives the text on stdin and pasteCommand prints it on stdout.
+func NewCommandClipboard(copyCommand, pasteCommand string) (*CommandClipboard, error) {
+	copyArgs := strings.Fields(copyCommand)
+	pasteArgs := strings.Fields(pasteCommand)
+
+	if len(copyArgs) == 0 || len(pasteArgs) == 0 {
+		return nil, fmt.Errorf("command clipboard needs both a copy and a paste command")
+	}
+
+	return &CommandClipboard{copyArgs: copyArgs, pasteArgs: pasteArgs}, nil
+}
+
+// Read runs the paste command and returns its output
+func (c *CommandClipboard) Read() (string, error) {
+	out, err := c.paste(lemon.TextMimeType, lemon.SelectionClipboard)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// Write runs the copy command with text on its stdin
+func (c *CommandClipboard) Write(text string) error {
+	return c.copy(lemon.TextMimeType, lemon.SelectionClipboard, []byte(text))
+}
+
+func (c *CommandClipboard) paste(mimeType, selection string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	args := withPlaceholders(c.pasteArgs, mimeType, selection)
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("cannot run %s: %s: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
+// copy runs the copy command with data on its stdin.
+// Its output isn't captured since tools like xclip keep running in the background.
+func (c *CommandClipboard) copy(mimeType, selection string, data []byte) error {
+	args := withPlaceholders(c.copyArgs, mimeType, selection)
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(data)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("cannot run %s: %s", args[0], err)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// CharsetClipboard keeps the text of another Clipboard in a character set other than UTF-8,
+// e.g. for legacy tools behind the command backend
+type CharsetClipboard struct {
+	clipboard Clipboard
+	charset   *lemon.Charset
+}
+
+// NewCharsetClipboard returns a new CharsetClipboard converting the text of clipboard to charset
+func NewCharsetClipboard(clipboard Clipboard, charset *lemon.Charset) *CharsetClipboard {
+	return &CharsetClipboard{clipboard: clipboard, charset: charset}
+}
+
+// Read returns the clipboard text converted to UTF-8
+func (c *CharsetClipboard) Read() (string, error) {
+	text, err := c.clipboard.Read()
+	if err != nil {
+		return "", err
+	}
+
+	return c.charset.Decode(text)
+}
+
+// Write converts text to the character set of the clipboard
+func (c *CharsetClipboard) Write(text string) error {
+	encoded, err := c.charset.Encode(text)
+	if err != nil {
+		return err
+	}
+
+	return c.clipboard.Write(encoded)
+}
+
+// WriteFormats converts the plain text payload to the character set of the clipboard
+func (c *CharsetClipboard) WriteFormats(payloads []*Payload) error {
+	cb, ok := c.clipboard.(FormatClipboard)
+	if !ok {
+		return ErrFormatUnsupported
+	}
+
+	encoded := make([]*Payload, len(payloads))
+
+	for i, payload := range payloads {
+		encoded[i] = payload
+
+		if !lemon.IsText(payload.MimeType) {
+			continue
+		}
+
+		text, err := c.charset.Encode(string(payload.Data))
+		if err != nil {
+			return err
+		}
+
+		encoded[i] = &Payload{MimeType: payload.MimeType, Data: []byte(text)}
+	}
+
+	return cb.WriteFormats(encoded)
+}
+
+// ReadFormat returns the clipboard content, converting plain text to UTF-8
+func (c *CharsetClipboard) ReadFormat(mimeType string) ([]byte, error) {
+	if lemon.IsText(mimeType) {
+		text, err := c.Read()
+		return []byte(text), err
+	}
+
+	cb, ok := c.clipboard.(FormatClipboard)
+	if !ok {
+		return nil, ErrFormatUnsupported
+	}
+
+	return cb.ReadFormat(mimeType)
+}
+
+// WriteSelection converts text to the character set of the clipboard
+func (c *CharsetClipboard) WriteSelection(selection, text string) error {
+	if selection == lemon.SelectionClipboard {
+		return c.Write(text)
+	}
+
+	cb, ok := c.clipboard.(SelectionClipboard)
+	if !ok {
+		return ErrSelectionUnsupported
+	}
+
+	encoded, err := c.charset.Encode(text)
+	if err != nil {
+		return err
+	}
+
+	return cb.WriteSelection(selection, encoded)
+}
+
+// ReadSelection returns the text of a selection converted to UTF-8
+func (c *CharsetClipboard) ReadSelection(selection string) (string, error) {
+	if selection == lemon.SelectionClipboard {
+		return c.Read()
+	}
+
+	cb, ok := c.clipboard.(SelectionClipboard)
+	if !ok {
+		return "", ErrSelectionUnsupported
+	}
+
+	text, err := cb.ReadSelection(selection)
+	if err != nil {
+		return "", err
+	}
+
+	return c.charset.Decode(text)
+}
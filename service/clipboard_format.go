@@ -0,0 +1,258 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// mimeTypePlaceholder is replaced by a MIME type in clipboard commands
+const mimeTypePlaceholder = "{type}"
+
+var (
+	// ErrFormatNotFound is returned when the clipboard doesn't hold a format
+	ErrFormatNotFound = errors.New("clipboard doesn't hold this format")
+	// ErrFormatUnsupported is returned when a backend can only hold plain text
+	ErrFormatUnsupported = errors.New("clipboard backend only supports plain text")
+	// ErrSingleFormat is returned when several formats are copied to a backend holding one at once
+	ErrSingleFormat = errors.New("clipboard backend only holds a single format per copy")
+	// ErrRegisterFormat is returned when other formats than plain text are copied to a named register
+	ErrRegisterFormat = errors.New("named registers only hold plain text")
+)
+
+// Payload is clipboard content in a given format
+type Payload struct {
+	MimeType string
+	Data     []byte
+}
+
+// FormatClipboard is implemented by Clipboard backends holding other formats than plain text
+type FormatClipboard interface {
+	Clipboard
+	// WriteFormats replaces the clipboard with the same content in several formats
+	WriteFormats(payloads []*Payload) error
+	// ReadFormat returns the clipboard content in the given format
+	ReadFormat(mimeType string) ([]byte, error)
+}
+
+// splitPayloads returns the plain text of payloads and the other formats
+func splitPayloads(payloads []*Payload) (string, []*Payload) {
+	var (
+		text  string
+		other []*Payload
+	)
+
+	for _, payload := range payloads {
+		if lemon.IsText(payload.MimeType) {
+			text = string(payload.Data)
+		} else {
+			other = append(other, payload)
+		}
+	}
+
+	return text, other
+}
+
+// WriteFormats stores all payloads
+func (c *MemoryClipboard) WriteFormats(payloads []*Payload) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	text, other := splitPayloads(payloads)
+
+	c.text = text
+	c.formats = make(map[string][]byte)
+
+	for _, payload := range other {
+		c.formats[lemon.MediaType(payload.MimeType)] = payload.Data
+	}
+
+	return nil
+}
+
+// ReadFormat returns the stored payload of mimeType
+func (c *MemoryClipboard) ReadFormat(mimeType string) ([]byte, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if lemon.IsText(mimeType) {
+		return []byte(c.text), nil
+	}
+
+	data, ok := c.formats[lemon.MediaType(mimeType)]
+	if !ok {
+		return nil, ErrFormatNotFound
+	}
+
+	return data, nil
+}
+
+// WriteFormats writes plain text to the clipboard file and every other format next to it
+func (c *FileClipboard) WriteFormats(payloads []*Payload) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.removeFormats(); err != nil {
+		return err
+	}
+
+	text, other := splitPayloads(payloads)
+
+	if err := ioutil.WriteFile(c.path, []byte(text), 0600); err != nil {
+		return fmt.Errorf("cannot write clipboard file: %s", err)
+	}
+
+	for _, payload := range other {
+		if err := ioutil.WriteFile(c.formatPath(payload.MimeType), payload.Data, 0600); err != nil {
+			return fmt.Errorf("cannot write clipboard file: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// ReadFormat returns the content of the file holding mimeType
+func (c *FileClipboard) ReadFormat(mimeType string) ([]byte, error) {
+	if lemon.IsText(mimeType) {
+		text, err := c.Read()
+		return []byte(text), err
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	data, err := ioutil.ReadFile(c.formatPath(mimeType))
+	if os.IsNotExist(err) {
+		return nil, ErrFormatNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read clipboard file: %s", err)
+	}
+
+	return data, nil
+}
+
+// formatPath returns the file holding mimeType, e.g. clipboard.image_png
+func (c *FileClipboard) formatPath(mimeType string) string {
+	return c.path + "." + strings.NewReplacer("/", "_", "\\", "_").Replace(lemon.MediaType(mimeType))
+}
+
+// removeFormats removes the files holding the other formats of the previous copy
+func (c *FileClipboard) removeFormats() error {
+	paths, err := filepath.Glob(c.path + ".*_*")
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("cannot remove clipboard file: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// WriteFormats runs the copy command with the payload which isn't plain text.
+// Clipboard tools only take a single format at once, so other payloads are refused
+// instead of being dropped.
+func (c *CommandClipboard) WriteFormats(payloads []*Payload) error {
+	text, other := splitPayloads(payloads)
+	if len(other) == 0 {
+		return c.Write(text)
+	}
+
+	if !hasPlaceholder(c.copyArgs, mimeTypePlaceholder) {
+		return ErrFormatUnsupported
+	}
+
+	if len(payloads) > 1 {
+		return ErrSingleFormat
+	}
+
+	return c.copy(other[0].MimeType, lemon.SelectionClipboard, other[0].Data)
+}
+
+// ReadFormat runs the paste command for mimeType
+func (c *CommandClipboard) ReadFormat(mimeType string) ([]byte, error) {
+	if !lemon.IsText(mimeType) && !hasPlaceholder(c.pasteArgs, mimeTypePlaceholder) {
+		return nil, ErrFormatUnsupported
+	}
+
+	return c.paste(mimeType, lemon.SelectionClipboard)
+}
+
+// WriteFormats copies other formats than plain text with wl-copy or xclip
+func (c *SystemClipboard) WriteFormats(payloads []*Payload) error {
+	text, other := splitPayloads(payloads)
+	if len(other) == 0 {
+		return c.Write(text)
+	}
+
+	tool, err := formatTool()
+	if err != nil {
+		return err
+	}
+
+	return tool.WriteFormats(payloads)
+}
+
+// ReadFormat pastes other formats than plain text with wl-paste or xclip
+func (c *SystemClipboard) ReadFormat(mimeType string) ([]byte, error) {
+	if lemon.IsText(mimeType) {
+		text, err := c.Read()
+		return []byte(text), err
+	}
+
+	tool, err := formatTool()
+	if err != nil {
+		return nil, err
+	}
+
+	return tool.ReadFormat(mimeType)
+}
+
+// formatTool returns the clipboard tool of the desktop able to handle any format
+func formatTool() (*CommandClipboard, error) {
+	switch {
+	case os.Getenv("WAYLAND_DISPLAY") != "" && hasCommand("wl-copy"):
+		return NewCommandClipboard("wl-copy --type {type}", "wl-paste --no-newline --type {type}")
+	case os.Getenv("DISPLAY") != "" && hasCommand("xclip"):
+		return NewCommandClipboard("xclip -selection clipboard -t {type} -i", "xclip -selection clipboard -t {type} -o")
+	default:
+		return nil, ErrFormatUnsupported
+	}
+}
+
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+func hasPlaceholder(args []string, placeholder string) bool {
+	for _, arg := range args {
+		if strings.Contains(arg, placeholder) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withPlaceholders replaces the {type} and {selection} placeholders of a command
+func withPlaceholders(args []string, mimeType, selection string) []string {
+	replacer := strings.NewReplacer(mimeTypePlaceholder, lemon.MediaType(mimeType), selectionPlaceholder, selection)
+	replaced := make([]string, len(args))
+
+	for i, arg := range args {
+		replaced[i] = replacer.Replace(arg)
+	}
+
+	return replaced
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// selectionPlaceholder is replaced by an X11 selection in clipboard commands
+const selectionPlaceholder = "{selection}"
+
+var (
+	// ErrSelectionUnsupported is returned when a backend only holds the clipboard selection
+	ErrSelectionUnsupported = errors.New("clipboard backend only supports the clipboard selection")
+	// ErrRegisterSelection is returned when a selection is given with a named register
+	ErrRegisterSelection = errors.New("named registers have no selection")
+	// ErrSelectionFormat is returned when other formats than plain text are copied to another selection than the clipboard
+	ErrSelectionFormat = errors.New("only the clipboard selection holds other formats than plain text")
+	// ErrHistorySelection is returned when a history entry is pasted from another selection than the clipboard
+	ErrHistorySelection = errors.New("history only holds copies of the clipboard selection")
+)
+
+// SelectionClipboard is implemented by Clipboard backends holding the X11 PRIMARY and SECONDARY selections
+type SelectionClipboard interface {
+	Clipboard
+	// WriteSelection replaces the content of a selection
+	WriteSelection(selection, text string) error
+	// ReadSelection returns the content of a selection
+	ReadSelection(selection string) (string, error)
+}
+
+// WriteSelection replaces the stored text of a selection
+func (c *MemoryClipboard) WriteSelection(selection, text string) error {
+	if selection == lemon.SelectionClipboard {
+		return c.Write(text)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.selections == nil {
+		c.selections = make(map[string]string)
+	}
+
+	c.selections[selection] = text
+
+	return nil
+}
+
+// ReadSelection returns the stored text of a selection
+func (c *MemoryClipboard) ReadSelection(selection string) (string, error) {
+	if selection == lemon.SelectionClipboard {
+		return c.Read()
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.selections[selection], nil
+}
+
+// WriteSelection writes a selection to a file next to the clipboard file, e.g. clipboard.primary
+func (c *FileClipboard) WriteSelection(selection, text string) error {
+	if selection == lemon.SelectionClipboard {
+		return c.Write(text)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := ioutil.WriteFile(c.path+"."+selection, []byte(text), 0600); err != nil {
+		return fmt.Errorf("cannot write clipboard file: %s", err)
+	}
+
+	return nil
+}
+
+// ReadSelection returns the content of the file holding a selection
+func (c *FileClipboard) ReadSelection(selection string) (string, error) {
+	if selection == lemon.SelectionClipboard {
+		return c.Read()
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	b, err := ioutil.ReadFile(c.path + "." + selection)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot read clipboard file: %s", err)
+	}
+
+	return string(b), nil
+}
+
+// WriteSelection runs the copy command for a selection
+func (c *CommandClipboard) WriteSelection(selection, text string) error {
+	if selection != lemon.SelectionClipboard && !hasPlaceholder(c.copyArgs, selectionPlaceholder) {
+		return ErrSelectionUnsupported
+	}
+
+	return c.copy(lemon.TextMimeType, selection, []byte(text))
+}
+
+// ReadSelection runs the paste command for a selection
+func (c *CommandClipboard) ReadSelection(selection string) (string, error) {
+	if selection != lemon.SelectionClipboard && !hasPlaceholder(c.pasteArgs, selectionPlaceholder) {
+		return "", ErrSelectionUnsupported
+	}
+
+	out, err := c.paste(lemon.TextMimeType, selection)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// WriteSelection copies to other selections than the clipboard with wl-copy or xclip
+func (c *SystemClipboard) WriteSelection(selection, text string) error {
+	if selection == lemon.SelectionClipboard {
+		return c.Write(text)
+	}
+
+	tool, err := selectionTool(selection)
+	if err != nil {
+		return err
+	}
+
+	return tool.Write(text)
+}
+
+// ReadSelection pastes other selections than the clipboard with wl-paste or xclip
+func (c *SystemClipboard) ReadSelection(selection string) (string, error) {
+	if selection == lemon.SelectionClipboard {
+		return c.Read()
+	}
+
+	tool, err := selectionTool(selection)
+	if err != nil {
+		return "", err
+	}
+
+	return tool.Read()
+}
+
+// selectionTool returns the clipboard tool of the desktop able to handle a selection.
+// Wayland has no SECONDARY selection.
+func selectionTool(selection string) (*CommandClipboard, error) {
+	switch {
+	case os.Getenv("WAYLAND_DISPLAY") != "" && hasCommand("wl-copy") && selection == lemon.SelectionPrimary:
+		return NewCommandClipboard("wl-copy --primary", "wl-paste --no-newline --primary")
+	case os.Getenv("DISPLAY") != "" && hasCommand("xclip"):
+		return NewCommandClipboard("xclip -selection "+selection+" -i", "xclip -selection "+selection+" -o")
+	default:
+		return nil, ErrSelectionUnsupported
+	}
+}
@@ -0,0 +1,253 @@
+package service_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jrc2139/vimonade/service"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "vimonade")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestClipboardBackends(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "clipboard")
+
+	command, err := service.NewCommandClipboard("tee "+path, "cat "+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backends := map[string]service.Clipboard{
+		"memory":  service.NewMemoryClipboard(),
+		"file":    service.NewFileClipboard(filepath.Join(dir, "file")),
+		"command": command,
+	}
+
+	for name, cb := range backends {
+		if err := cb.Write("hoge\nfuga\n"); err != nil {
+			t.Fatalf("%s: cannot write: %v", name, err)
+		}
+
+		got, err := cb.Read()
+		if err != nil {
+			t.Fatalf("%s: cannot read: %v", name, err)
+		}
+
+		if got != "hoge\nfuga\n" {
+			t.Errorf("%s: Expected: %q, got %q", name, "hoge\nfuga\n", got)
+		}
+	}
+}
+
+func TestNewClipboard(t *testing.T) {
+	logger := zap.NewNop()
+
+	if _, err := service.NewClipboard(service.ClipboardConfig{Backend: "unknown"}, logger); err == nil {
+		t.Error("Expected an error for an unknown backend")
+	}
+
+	if _, err := service.NewClipboard(service.ClipboardConfig{Backend: service.CommandBackend}, logger); err == nil {
+		t.Error("Expected an error for a command backend without commands")
+	}
+
+	if runtime.GOOS != "linux" {
+		return
+	}
+
+	for _, key := range []string{"DISPLAY", "WAYLAND_DISPLAY"} {
+		defer os.Setenv(key, os.Getenv(key))
+		os.Unsetenv(key)
+	}
+
+	cb, err := service.NewClipboard(service.ClipboardConfig{Backend: service.SystemBackend}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cb.(*service.FileClipboard); !ok {
+		t.Errorf("Expected the file backend without a display, got %T", cb)
+	}
+}
+
+func TestClipboardFormats(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	command, err := service.NewCommandClipboard("tee "+filepath.Join(dir, "command"), "cat "+filepath.Join(dir, "command"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := command.WriteFormats([]*service.Payload{{MimeType: "image/png", Data: []byte{0x89}}}); err != service.ErrFormatUnsupported {
+		t.Errorf("Expected ErrFormatUnsupported without a {type} placeholder, got %v", err)
+	}
+
+	// {type} is replaced by e.g. image/png
+	if err := os.Mkdir(filepath.Join(dir, "image"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	typed, err := service.NewCommandClipboard("tee "+filepath.Join(dir, "{type}"), "cat "+filepath.Join(dir, "{type}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// clipboard tools can't hold the text with the image, which mustn't be dropped silently
+	if err := typed.WriteFormats([]*service.Payload{
+		{MimeType: "text/plain", Data: []byte("plot")},
+		{MimeType: "image/png", Data: []byte{0x89}},
+	}); err != service.ErrSingleFormat {
+		t.Errorf("Expected ErrSingleFormat for several formats, got %v", err)
+	}
+
+	if err := typed.WriteFormats([]*service.Payload{{MimeType: "image/png", Data: []byte{0x89}}}); err != nil {
+		t.Errorf("Expected a single format to be copied, got %v", err)
+	}
+
+	backends := map[string]service.FormatClipboard{
+		"memory": service.NewMemoryClipboard(),
+		"file":   service.NewFileClipboard(filepath.Join(dir, "file")),
+	}
+
+	png := []byte{0x89, 'P', 'N', 'G', 0xff}
+
+	for name, cb := range backends {
+		if err := cb.WriteFormats([]*service.Payload{
+			{MimeType: "text/plain; charset=utf-8", Data: []byte("plot")},
+			{MimeType: "image/png", Data: png},
+		}); err != nil {
+			t.Fatalf("%s: cannot write: %v", name, err)
+		}
+
+		if text, _ := cb.Read(); text != "plot" {
+			t.Errorf("%s: Expected: %q, got %q", name, "plot", text)
+		}
+
+		if data, err := cb.ReadFormat("image/png"); err != nil || !bytes.Equal(data, png) {
+			t.Errorf("%s: Expected: %v, got %v (%v)", name, png, data, err)
+		}
+
+		// a new copy drops the previous formats
+		if err := cb.Write("text"); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := cb.ReadFormat("image/png"); err != service.ErrFormatNotFound {
+			t.Errorf("%s: Expected ErrFormatNotFound, got %v", name, err)
+		}
+	}
+}
+
+func TestClipboardSelections(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "command.{selection}")
+
+	command, err := service.NewCommandClipboard("tee "+path, "cat "+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backends := map[string]service.SelectionClipboard{
+		"memory":  service.NewMemoryClipboard(),
+		"file":    service.NewFileClipboard(filepath.Join(dir, "file")),
+		"command": command,
+	}
+
+	for name, cb := range backends {
+		if err := cb.Write("clipboard"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := cb.WriteSelection("primary", "primary"); err != nil {
+			t.Fatalf("%s: cannot write: %v", name, err)
+		}
+
+		for _, selection := range []string{"clipboard", "primary"} {
+			if text, err := cb.ReadSelection(selection); err != nil || text != selection {
+				t.Errorf("%s: Expected: %q, got %q (%v)", name, selection, text, err)
+			}
+		}
+	}
+
+	command, err = service.NewCommandClipboard("tee "+filepath.Join(dir, "command"), "cat "+filepath.Join(dir, "command"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := command.WriteSelection("primary", "primary"); err != service.ErrSelectionUnsupported {
+		t.Errorf("Expected ErrSelectionUnsupported without a {selection} placeholder, got %v", err)
+	}
+}
+
+func TestCharsetClipboard(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	for _, tc := range []struct {
+		charset string
+		encoded string
+	}{
+		{"shift_jis", "\x82\xb1\x82\xf1\x82\xc9\x82\xbf\x82\xcd\n"},
+		{"utf-16le", "S0\x930k0a0o0\n\x00"},
+		{"euc-jp", "\xa4\xb3\xa4\xf3\xa4\xcb\xa4\xc1\xa4\xcf\n"},
+	} {
+		path := filepath.Join(dir, tc.charset)
+
+		cb, err := service.NewClipboard(service.ClipboardConfig{Backend: service.FileBackend, FilePath: path, Charset: tc.charset}, zap.NewNop())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := cb.Write("こんにちは\n"); err != nil {
+			t.Fatal(err)
+		}
+
+		if b, _ := ioutil.ReadFile(path); string(b) != tc.encoded {
+			t.Errorf("%s: Expected: %q, got %q", tc.charset, tc.encoded, b)
+		}
+
+		if text, err := cb.Read(); err != nil || text != "こんにちは\n" {
+			t.Errorf("%s: Expected: %q, got %q (%v)", tc.charset, "こんにちは\n", text, err)
+		}
+
+		if err := cb.(service.SelectionClipboard).WriteSelection("primary", "日本"); err != nil {
+			t.Fatal(err)
+		}
+
+		if text, err := cb.(service.SelectionClipboard).ReadSelection("primary"); err != nil || text != "日本" {
+			t.Errorf("%s: Expected: %q, got %q (%v)", tc.charset, "日本", text, err)
+		}
+	}
+
+	if _, err := service.NewClipboard(service.ClipboardConfig{Backend: service.MemoryBackend, Charset: "klingon"}, zap.NewNop()); err == nil {
+		t.Error("Expected an error for an unknown charset")
+	}
+
+	for _, backend := range []string{"", service.SystemBackend, service.MemoryBackend} {
+		if _, err := service.NewClipboard(service.ClipboardConfig{Backend: backend, Charset: "shift_jis"}, zap.NewNop()); err == nil {
+			t.Errorf("%q: Expected an error for a charset of a UTF-8 backend", backend)
+		}
+	}
+
+	if _, err := service.NewClipboard(service.ClipboardConfig{Backend: service.SystemBackend, Charset: "utf-8"}, zap.NewNop()); err != nil {
+		t.Errorf("Expected UTF-8 to be supported by the system backend, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+const (
+	// defaultSensitiveTTL is how long a sensitive copy without ttl stays in the clipboard
+	defaultSensitiveTTL = 30 * time.Second
+	// ExpiryOrigin is the origin of the changes made when a copy expires
+	ExpiryOrigin = "expiry"
+)
+
+// ErrRegisterTTL is returned when a named register is given a ttl
+var ErrRegisterTTL = errors.New("named registers can't expire")
+
+// expiry is a pending restore of the content of a selection
+type expiry struct {
+	timer    *time.Timer
+	register *Register
+	previous *Register
+}
+
+// copyTTL returns how long a copy stays in the clipboard, 0 being forever
+func copyTTL(sensitive bool, ttl int64) time.Duration {
+	if ttl <= 0 && sensitive {
+		return defaultSensitiveTTL
+	}
+
+	return time.Duration(ttl) * time.Millisecond
+}
+
+// previousRegister returns the content of a selection to restore once the copy replacing it expires.
+// The content of a pending expiry is never restored itself. It must be called with s.mutex held.
+func (s *vimonadeServiceServer) previousRegister(selection string) *Register {
+	text, err := s.readSelection(selection)
+	if err != nil {
+		s.logger.Debug("Reading the clipboard to restore failed: " + err.Error())
+		return &Register{}
+	}
+
+	if pending, ok := s.expiries[selection]; ok && pending.register.Value == text {
+		return pending.previous
+	}
+
+	if last, ok := s.lastCopy[selection]; ok && last.Value == text {
+		return last
+	}
+
+	return &Register{Value: text}
+}
+
+// expiring reports whether the content of a selection is pending expiry
+func (s *vimonadeServiceServer) expiring(selection string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.expiries[selection]
+
+	return ok
+}
+
+// cancelExpiry stops the pending expiry of a selection. It must be called with s.mutex held.
+func (s *vimonadeServiceServer) cancelExpiry(selection string) {
+	if pending, ok := s.expiries[selection]; ok {
+		pending.timer.Stop()
+		delete(s.expiries, selection)
+	}
+}
+
+// expireAfter restores previous once ttl has passed. It must be called with s.mutex held.
+func (s *vimonadeServiceServer) expireAfter(selection string, register, previous *Register, ttl time.Duration) {
+	pending := &expiry{register: register, previous: previous}
+	pending.timer = time.AfterFunc(ttl, func() { s.expire(selection, pending) })
+
+	s.expiries[selection] = pending
+}
+
+// expire restores the previous content of a selection,
+// unless something newer was copied in the meantime
+func (s *vimonadeServiceServer) expire(selection string, pending *expiry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.expiries[selection] != pending {
+		return
+	}
+
+	delete(s.expiries, selection)
+
+	if s.lastCopy[selection] != pending.register {
+		return
+	}
+
+	// the clipboard may have been changed by another application since
+	if text, err := s.readSelection(selection); err != nil || text != pending.register.Value {
+		return
+	}
+
+	if err := s.writeSelection(selection, pending.previous.Value); err != nil {
+		s.logger.Error("Restoring the clipboard failed: " + err.Error())
+		return
+	}
+
+	s.lastCopy[selection] = pending.previous
+
+	s.logger.Debug("Copy expired: selection: " + selection)
+
+	if selection == lemon.SelectionClipboard {
+		s.watcher.Publish(&Event{
+			Register: "+",
+			Value:    pending.previous.Value,
+			Regtype:  pending.previous.Regtype,
+			Origin:   ExpiryOrigin,
+			Time:     time.Now(),
+		})
+	}
+}
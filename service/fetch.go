@@ -0,0 +1,77 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+)
+
+func (s *vimonadeServiceServer) Fetch(message *pb.FetchRequest, stream pb.VimonadeService_FetchServer) error {
+	if err := s.contextError(stream.Context()); err != nil {
+		return err
+	}
+
+	name := message.GetName()
+
+	s.logger.Info("receive a fetch-file request for " + name)
+
+	file, size, err := s.fileStore.Open(name)
+	if err != nil {
+		return logError(fileError(err))
+	}
+	defer file.Close()
+
+	// the client checks the file against the digest, computed up to the size sent
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.LimitReader(file, size)); err != nil {
+		return logError(status.Errorf(codes.Internal, "cannot read file: %v", err))
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return logError(status.Errorf(codes.Internal, "cannot read file: %v", err))
+	}
+
+	info := &pb.FileInfo{
+		Name:     name,
+		FileType: filepath.Ext(name),
+		Size:     uint64(size),
+		Sha256:   hex.EncodeToString(hash.Sum(nil)),
+	}
+
+	if err := stream.Send(&pb.FetchChunk{Data: &pb.FetchChunk_Info{Info: info}}); err != nil {
+		return logError(status.Errorf(codes.Unknown, "cannot send file info: %v", err))
+	}
+
+	reader := io.LimitReader(file, size)
+	buffer := make([]byte, chunkSize)
+
+	for {
+		if err := s.contextError(stream.Context()); err != nil {
+			return err
+		}
+
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			if err := stream.Send(&pb.FetchChunk{Data: &pb.FetchChunk_ChunkData{ChunkData: buffer[:n]}}); err != nil {
+				return logError(status.Errorf(codes.Unknown, "cannot send chunk data: %v", err))
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Internal, "cannot read file: %v", err))
+		}
+	}
+
+	s.logger.Debug(fmt.Sprintf("sent file %s with size %d, sha256: %s", name, size, info.GetSha256()))
+
+	return nil
+}
@@ -1,67 +1,605 @@
 package service
 
 import (
-	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
+)
+
+// Policies applied when a file is sent with the name of a stored file
+const (
+	CollisionRename    = "rename"
+	CollisionOverwrite = "overwrite"
+	CollisionReject    = "reject"
+)
+
+const (
+	// DefaultMaxFileSize is the default size limit of a stored file
+	DefaultMaxFileSize = 1 << 30
+
+	maxFileNameLength = 255
+	// maxRenames is the number of suffixes tried before giving up renaming a file
+	maxRenames = 1000
+	// tempFilePattern names the files being written, hidden in the store folder
+	tempFilePattern = ".vimonade-*.part"
+	// DefaultUploadTTL is the default time a partial upload is kept without receiving anything
+	DefaultUploadTTL = 24 * time.Hour
+
+	maxUploadIDLength = 64
+)
+
+var (
+	// ErrFileName is returned when a file name doesn't name a file in the store
+	ErrFileName = errors.New("invalid file name")
+	// ErrFileExists is returned when a file is already stored under a name and collisions are rejected
+	ErrFileExists = errors.New("file already exists")
+	// ErrFileTooLarge is returned when a file is written past the size limit of the store
+	ErrFileTooLarge = errors.New("file is too large")
+	// ErrFileDigest is returned when a file doesn't match the SHA-256 digest it was sent with
+	ErrFileDigest = errors.New("file content doesn't match its SHA-256 digest")
+	// ErrFileNotFound is returned when a file isn't in the store
+	ErrFileNotFound = errors.New("file not found")
+	// ErrFileClosed is returned when a file is written after it was committed or aborted
+	ErrFileClosed = errors.New("file is already committed or aborted")
+	// ErrUploadID is returned for upload IDs which aren't 1 to 64 letters, digits, '-' or '_'
+	ErrUploadID = errors.New("invalid upload id")
+	// ErrUploadNotFound is returned when an upload was never started, or expired
+	ErrUploadNotFound = errors.New("upload not found")
+	// ErrUploadOffset is returned when an upload is resumed past the content received so far
+	ErrUploadOffset = errors.New("upload offset is past the received content")
+	// ErrUploadBusy is returned when an upload is resumed while it's still being received
+	ErrUploadBusy = errors.New("upload is already in progress")
 )
 
 // FileStore is an interface to store laptop files
 type FileStore interface {
-	// Save saves a new laptop file to the store
-	Save(name string, fileType string, fileData bytes.Buffer) (string, error)
+	// Create starts storing a file, whose content is written to the returned FileWriter
+	Create(name string, fileType string) (FileWriter, error)
+	// Resume continues a resumable upload after its first offset bytes, starting it if it's new
+	Resume(uploadID, name, fileType string, offset int64) (FileWriter, error)
+	// UploadOffset returns the number of bytes received by a resumable upload
+	UploadOffset(uploadID string) (int64, error)
+	// Open opens a stored file for reading and returns its size
+	Open(name string) (FileReader, int64, error)
+}
+
+// FileReader reads a stored file
+type FileReader interface {
+	io.ReadSeeker
+	io.Closer
+}
+
+// FileWriter receives the content of a file until it's committed to the store or aborted
+type FileWriter interface {
+	io.Writer
+	// Digest returns the hex SHA-256 digest of the content written so far,
+	// including what a resumed upload received before
+	Digest() string
+	// Commit stores the file and returns the name it's stored under.
+	// The file is discarded if it doesn't match digest, unless digest is empty.
+	Commit(digest string) (string, error)
+	// Abort discards the file, or keeps the partial file of a resumable upload.
+	// It does nothing once the file is committed.
+	Abort() error
+}
+
+// FileStoreConfig describes how a DiskFileStore stores files
+type FileStoreConfig struct {
+	// Collision is the policy applied to files named like a stored file, rename when empty
+	Collision string
+	// MaxSize is the size limit of a file in bytes, 0 for no limit
+	MaxSize int64
+	// UploadTTL is the time a partial upload is kept without receiving anything, 0 forever
+	UploadTTL time.Duration
 }
 
 // DiskFileStore stores file on disk, and its info on memory
 type DiskFileStore struct {
 	mutex      sync.RWMutex
 	fileFolder string
-	files      map[string]*FileInfo
+	collision  string
+	maxSize    int64
+	uploadTTL  time.Duration
+	// uploads holds the resumable uploads being received
+	uploads map[string]bool
 }
 
-// FileInfo contains information of the laptop file
-type FileInfo struct {
-	Name string
-	Type string
-	Path string
-}
+// NewDiskFileStore returns a new DiskFileStore storing files in fileFolder as described by config
+func NewDiskFileStore(fileFolder string, config FileStoreConfig) (*DiskFileStore, error) {
+	collision := config.Collision
+
+	switch collision {
+	case "":
+		collision = CollisionRename
+	case CollisionRename, CollisionOverwrite, CollisionReject:
+	default:
+		return nil, fmt.Errorf("unknown collision policy: %s", collision)
+	}
+
+	if config.MaxSize < 0 {
+		return nil, fmt.Errorf("invalid file size limit: %d", config.MaxSize)
+	}
+
+	if config.UploadTTL < 0 {
+		return nil, fmt.Errorf("invalid upload ttl: %s", config.UploadTTL)
+	}
 
-// NewDiskFileStore returns a new DiskFileStore
-func NewDiskFileStore(fileFolder string) *DiskFileStore {
 	return &DiskFileStore{
 		fileFolder: fileFolder,
-		files:      make(map[string]*FileInfo),
+		collision:  collision,
+		maxSize:    config.MaxSize,
+		uploadTTL:  config.UploadTTL,
+		uploads:    make(map[string]bool),
+	}, nil
+}
+
+// SanitizeFileName returns the base name of a client supplied file name, with either / or \ as separator
+// and without a drive letter, or ErrFileName if nothing is left to name a file
+func SanitizeFileName(name string) (string, error) {
+	name = path.Base(strings.Replace(name, `\`, "/", -1))
+
+	// drive letters and NTFS alternate data streams
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	// Windows ignores trailing dots and spaces
+	name = strings.TrimRight(name, ". ")
+
+	if name == "" || name == "/" || len(name) > maxFileNameLength {
+		return "", ErrFileName
+	}
+
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return "", ErrFileName
+		}
 	}
+
+	return name, nil
 }
 
-// Save adds a new file to a laptop
-func (store *DiskFileStore) Save(
-	name string,
-	fileType string,
-	fileData bytes.Buffer,
-) (string, error) {
-	// filePath := fmt.Sprintf("%s/%s%s", store.fileFolder, name, fileType)
-	filePath := fmt.Sprintf("%s/%s", store.fileFolder, name)
+// Create starts writing a file to a temporary file of the store folder.
+// It's stored under the base name of name once committed.
+func (store *DiskFileStore) Create(name string, fileType string) (FileWriter, error) {
+	name, err := SanitizeFileName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := store.path(name); err != nil {
+		return nil, err
+	}
+
+	file, err := ioutil.TempFile(store.fileFolder, tempFilePattern)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create file: %s", err)
+	}
+
+	return &diskFileWriter{store: store, name: name, file: file, hash: sha256.New()}, nil
+}
+
+// Open opens a stored file. name must be the base name the file is stored under:
+// other paths, partial files and anything but regular files aren't found.
+func (store *DiskFileStore) Open(name string) (FileReader, int64, error) {
+	if sanitized, err := SanitizeFileName(name); err != nil || sanitized != name {
+		return nil, 0, ErrFileName
+	}
+
+	if matched, _ := filepath.Match(tempFilePattern, name); matched {
+		return nil, 0, ErrFileNotFound
+	}
+
+	filePath, err := store.path(name)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// symbolic links aren't followed out of the store
+	info, err := os.Lstat(filePath)
+	if os.IsNotExist(err) {
+		return nil, 0, ErrFileNotFound
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot open file: %s", err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil, 0, ErrFileNotFound
+	}
 
-	file, err := os.Create(filePath)
+	file, err := os.Open(filePath)
 	if err != nil {
-		return "", fmt.Errorf("cannot create file file: %s", err)
+		return nil, 0, fmt.Errorf("cannot open file: %s", err)
 	}
 
-	_, err = fileData.WriteTo(file)
+	// the file must still be the one checked above
+	opened, err := file.Stat()
+	if err != nil || !os.SameFile(info, opened) {
+		file.Close()
+		return nil, 0, ErrFileNotFound
+	}
+
+	return file, opened.Size(), nil
+}
+
+// Resume opens the partial file of a resumable upload, dropping what it has past offset.
+// It's stored under the base name of name once committed.
+func (store *DiskFileStore) Resume(uploadID, name, fileType string, offset int64) (FileWriter, error) {
+	if !validUploadID(uploadID) {
+		return nil, ErrUploadID
+	}
+
+	name, err := SanitizeFileName(name)
 	if err != nil {
-		return "", fmt.Errorf("cannot write file to file: %s", err)
+		return nil, err
+	}
+
+	if _, err := store.path(name); err != nil {
+		return nil, err
 	}
 
+	store.ExpireUploads()
+
 	store.mutex.Lock()
 	defer store.mutex.Unlock()
 
-	store.files[name] = &FileInfo{
-		Name: name,
-		Type: fileType,
-		Path: filePath,
+	if store.uploads[uploadID] {
+		return nil, ErrUploadBusy
+	}
+
+	partPath := store.uploadPath(uploadID)
+
+	info, err := os.Lstat(partPath)
+	if err == nil && !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("cannot resume upload: %s isn't a regular file", partPath)
+	}
+
+	created := os.IsNotExist(err)
+
+	file, err := os.OpenFile(partPath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create file: %s", err)
+	}
+
+	// a partial file created for an upload which can't be resumed isn't left for ExpireUploads
+	fail := func(err error) (FileWriter, error) {
+		file.Close()
+
+		if created {
+			os.Remove(partPath)
+		}
+
+		return nil, err
+	}
+
+	info, err = file.Stat()
+	if err != nil {
+		return fail(fmt.Errorf("cannot resume upload: %s", err))
+	}
+
+	if offset > info.Size() {
+		return fail(ErrUploadOffset)
+	}
+
+	if err := file.Truncate(offset); err != nil {
+		return fail(fmt.Errorf("cannot resume upload: %s", err))
+	}
+
+	// the digest covers the whole file, hashing what was received before
+	sum := sha256.New()
+	if _, err := io.CopyN(sum, file, offset); err != nil {
+		return fail(fmt.Errorf("cannot resume upload: %s", err))
+	}
+
+	store.uploads[uploadID] = true
+
+	return &diskFileWriter{store: store, name: name, file: file, size: offset, hash: sum, uploadID: uploadID}, nil
+}
+
+// UploadOffset returns the size of the partial file of a resumable upload
+func (store *DiskFileStore) UploadOffset(uploadID string) (int64, error) {
+	if !validUploadID(uploadID) {
+		return 0, ErrUploadID
+	}
+
+	store.ExpireUploads()
+
+	info, err := os.Lstat(store.uploadPath(uploadID))
+	if os.IsNotExist(err) {
+		return 0, ErrUploadNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("cannot find upload: %s", err)
+	}
+
+	return info.Size(), nil
+}
+
+// ExpireUploads removes the partial files which received nothing for the upload ttl,
+// including the temporary files left behind by a crash
+func (store *DiskFileStore) ExpireUploads() {
+	if store.uploadTTL == 0 {
+		return
+	}
+
+	paths, err := filepath.Glob(filepath.Join(store.fileFolder, tempFilePattern))
+	if err != nil {
+		return
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, partPath := range paths {
+		id := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(partPath), ".vimonade-upload-"), ".part")
+		if store.uploads[id] {
+			continue
+		}
+
+		if info, err := os.Lstat(partPath); err == nil && time.Since(info.ModTime()) > store.uploadTTL {
+			os.Remove(partPath)
+		}
+	}
+}
+
+// uploadPath returns the path of the partial file of an upload
+func (store *DiskFileStore) uploadPath(uploadID string) string {
+	return filepath.Join(store.fileFolder, ".vimonade-upload-"+uploadID+".part")
+}
+
+// validUploadID reports whether an upload ID can be part of a file name
+func validUploadID(uploadID string) bool {
+	if uploadID == "" || len(uploadID) > maxUploadIDLength {
+		return false
+	}
+
+	for _, r := range uploadID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// path returns the path of a sanitized name, making sure it's right in the store folder
+func (store *DiskFileStore) path(name string) (string, error) {
+	filePath := filepath.Join(store.fileFolder, name)
+
+	if rel, err := filepath.Rel(store.fileFolder, filePath); err != nil || rel != name {
+		return "", ErrFileName
+	}
+
+	return filePath, nil
+}
+
+// commit moves a written temporary file to its name, applying the collision policy.
+// Symbolic links are never followed.
+func (store *DiskFileStore) commit(tempPath, name string) (string, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var (
+		savedName string
+		err       error
+	)
+
+	if store.collision == CollisionOverwrite {
+		savedName, err = store.replace(tempPath, name)
+	} else {
+		savedName, err = store.link(tempPath, name)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	syncDir(store.fileFolder)
+
+	return savedName, nil
+}
+
+// link gives the temporary file a name which isn't taken, renaming it with a -N suffix
+// or failing when the name is taken
+func (store *DiskFileStore) link(tempPath, name string) (string, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	for i := 0; i <= maxRenames; i++ {
+		savedName := name
+		if i > 0 {
+			savedName = fmt.Sprintf("%s-%d%s", base, i, ext)
+		}
+
+		filePath, err := store.path(savedName)
+		if err != nil {
+			return "", err
+		}
+
+		// unlike a rename, a hard link fails on any existing entry, symbolic links included
+		err = os.Link(tempPath, filePath)
+		if os.IsExist(err) {
+			if store.collision == CollisionReject {
+				return "", ErrFileExists
+			}
+
+			continue
+		}
+
+		// file systems without hard links
+		if err != nil {
+			if _, lerr := os.Lstat(filePath); lerr == nil {
+				if store.collision == CollisionReject {
+					return "", ErrFileExists
+				}
+
+				continue
+			}
+
+			if err := os.Rename(tempPath, filePath); err != nil {
+				return "", fmt.Errorf("cannot store file: %s", err)
+			}
+
+			return savedName, nil
+		}
+
+		os.Remove(tempPath)
+
+		return savedName, nil
+	}
+
+	return "", fmt.Errorf("cannot rename file: too many files named %s", name)
+}
+
+// replace renames the temporary file over the file with a name,
+// which replaces a symbolic link instead of writing to its target
+func (store *DiskFileStore) replace(tempPath, name string) (string, error) {
+	filePath, err := store.path(name)
+	if err != nil {
+		return "", err
+	}
+
+	if info, err := os.Lstat(filePath); err == nil && info.IsDir() {
+		return "", ErrFileExists
+	}
+
+	if err := os.Rename(tempPath, filePath); err != nil {
+		return "", fmt.Errorf("cannot replace file: %s", err)
 	}
 
 	return name, nil
 }
+
+// diskFileWriter writes a file of a DiskFileStore to a temporary file,
+// or to the partial file of a resumable upload
+type diskFileWriter struct {
+	store    *DiskFileStore
+	name     string
+	file     *os.File
+	size     int64
+	done     bool
+	hash     hash.Hash
+	uploadID string
+}
+
+// Write appends p to the file, failing past the size limit of the store
+func (w *diskFileWriter) Write(p []byte) (int, error) {
+	if w.done {
+		return 0, ErrFileClosed
+	}
+
+	if w.store.maxSize > 0 && w.size+int64(len(p)) > w.store.maxSize {
+		return 0, ErrFileTooLarge
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	w.hash.Write(p[:n])
+
+	return n, err
+}
+
+// Digest returns the hex SHA-256 digest of the file
+func (w *diskFileWriter) Digest() string {
+	return hex.EncodeToString(w.hash.Sum(nil))
+}
+
+// Commit syncs the file to disk and atomically moves it to its name.
+// The file is removed if it can't be stored, or doesn't match digest.
+func (w *diskFileWriter) Commit(digest string) (string, error) {
+	if w.done {
+		return "", ErrFileClosed
+	}
+
+	w.done = true
+	defer w.release()
+
+	tempPath := w.file.Name()
+
+	// a corrupted upload can't be resumed either
+	if digest != "" && !strings.EqualFold(digest, w.Digest()) {
+		w.file.Close()
+		os.Remove(tempPath)
+
+		return "", ErrFileDigest
+	}
+
+	if err := w.file.Sync(); err != nil {
+		w.file.Close()
+		os.Remove(tempPath)
+
+		return "", fmt.Errorf("cannot write file: %s", err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		os.Remove(tempPath)
+		return "", fmt.Errorf("cannot write file: %s", err)
+	}
+
+	// temporary files are only readable by their owner
+	if err := os.Chmod(tempPath, 0644); err != nil {
+		os.Remove(tempPath)
+		return "", fmt.Errorf("cannot write file: %s", err)
+	}
+
+	name, err := w.store.commit(tempPath, w.name)
+	if err != nil {
+		os.Remove(tempPath)
+		return "", err
+	}
+
+	return name, nil
+}
+
+// Abort removes the temporary file, or keeps the partial file of a resumable upload
+func (w *diskFileWriter) Abort() error {
+	if w.done {
+		return nil
+	}
+
+	w.done = true
+	defer w.release()
+
+	if w.uploadID != "" {
+		w.file.Sync()
+		return w.file.Close()
+	}
+
+	w.file.Close()
+
+	return os.Remove(w.file.Name())
+}
+
+// release lets a resumable upload be resumed again
+func (w *diskFileWriter) release() {
+	if w.uploadID == "" {
+		return
+	}
+
+	w.store.mutex.Lock()
+	defer w.store.mutex.Unlock()
+
+	delete(w.store.uploads, w.uploadID)
+}
+
+// syncDir flushes the entries of a directory to disk, where the platform allows it
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
@@ -0,0 +1,381 @@
+package service_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jrc2139/vimonade/service"
+)
+
+func TestSanitizeFileName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"report.pdf":                  "report.pdf",
+		".bashrc":                     ".bashrc",
+		"../../.ssh/authorized_keys":  "authorized_keys",
+		`..\..\.ssh\authorized_keys`:  "authorized_keys",
+		"/etc/passwd":                 "passwd",
+		`C:\Windows\System32\cmd.exe`: "cmd.exe",
+		"C:cmd.exe":                   "cmd.exe",
+		"notes.txt:hidden":            "hidden",
+		"trailing. ":                  "trailing",
+	} {
+		got, err := service.SanitizeFileName(name)
+		if err != nil {
+			t.Errorf("%q: %v", name, err)
+			continue
+		}
+
+		if got != expected {
+			t.Errorf("%q: Expected: %q, got %q", name, expected, got)
+		}
+	}
+
+	for _, name := range []string{"", ".", "..", "/", `\`, "../", "a/..", "new\nline", "C:"} {
+		if _, err := service.SanitizeFileName(name); err != service.ErrFileName {
+			t.Errorf("%q: Expected ErrFileName, got %v", name, err)
+		}
+	}
+}
+
+func TestDiskFileStore(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	root := filepath.Join(dir, "files")
+	if err := os.Mkdir(root, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	save := func(store *service.DiskFileStore, name, content string) (string, error) {
+		writer, err := store.Create(name, filepath.Ext(name))
+		if err != nil {
+			return "", err
+		}
+		defer writer.Abort()
+
+		if _, err := writer.Write([]byte(content)); err != nil {
+			return "", err
+		}
+
+		return writer.Commit("")
+	}
+
+	assertFile := func(name, expected string) {
+		b, err := ioutil.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != expected {
+			t.Errorf("%s: Expected: %q, got %q", name, expected, b)
+		}
+	}
+
+	if _, err := service.NewDiskFileStore(root, service.FileStoreConfig{Collision: "merge"}); err == nil {
+		t.Error("Expected an error for an unknown collision policy")
+	}
+
+	store, err := service.NewDiskFileStore(root, service.FileStoreConfig{Collision: service.CollisionRename})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// names can't escape the root
+	if name, err := save(store, "../escape.txt", "hoge"); err != nil || name != "escape.txt" {
+		t.Fatalf("Expected escape.txt, got %q (%v)", name, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "escape.txt")); !os.IsNotExist(err) {
+		t.Error("Expected nothing to be written out of the root")
+	}
+
+	for _, expected := range []string{"escape-1.txt", "escape-2.txt"} {
+		if name, err := save(store, "escape.txt", expected); err != nil || name != expected {
+			t.Errorf("Expected %s, got %q (%v)", expected, name, err)
+		}
+	}
+
+	assertFile("escape.txt", "hoge")
+	assertFile("escape-2.txt", "escape-2.txt")
+
+	store, err = service.NewDiskFileStore(root, service.FileStoreConfig{Collision: service.CollisionReject})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := save(store, "escape.txt", "fuga"); err != service.ErrFileExists {
+		t.Errorf("Expected ErrFileExists, got %v", err)
+	}
+
+	assertFile("escape.txt", "hoge")
+
+	store, err = service.NewDiskFileStore(root, service.FileStoreConfig{Collision: service.CollisionOverwrite})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := save(store, "escape.txt", "fuga"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertFile("escape.txt", "fuga")
+
+	// the size limit aborts the write, leaving nothing behind
+	store, err = service.NewDiskFileStore(root, service.FileStoreConfig{Collision: service.CollisionRename, MaxSize: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := save(store, "large.txt", "hogefuga"); err != service.ErrFileTooLarge {
+		t.Errorf("Expected ErrFileTooLarge, got %v", err)
+	}
+
+	writer, err := store.Create("aborted.txt", ".txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writer.Write([]byte("hoge")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Abort(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ioutil.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		switch file.Name() {
+		case "escape.txt", "escape-1.txt", "escape-2.txt":
+		default:
+			t.Errorf("Expected no other file, got %s", file.Name())
+		}
+	}
+
+	// symbolic links in the root are replaced, never written through
+	target := filepath.Join(dir, "target")
+	if err := ioutil.WriteFile(target, []byte("target"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(target, filepath.Join(root, "link")); err != nil {
+		t.Skip("cannot create symbolic links: " + err.Error())
+	}
+
+	store, err = service.NewDiskFileStore(root, service.FileStoreConfig{Collision: service.CollisionOverwrite})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := save(store, "link", "fuga"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertFile("link", "fuga")
+
+	if b, _ := ioutil.ReadFile(target); string(b) != "target" {
+		t.Errorf("Expected the link target to be left alone, got %q", b)
+	}
+}
+
+func TestResumeUpload(t *testing.T) {
+	root := tempDir(t)
+	defer os.RemoveAll(root)
+
+	store, err := service.NewDiskFileStore(root, service.FileStoreConfig{UploadTTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.UploadOffset("upload1"); err != service.ErrUploadNotFound {
+		t.Errorf("Expected ErrUploadNotFound, got %v", err)
+	}
+
+	for _, id := range []string{"", "../upload", "upload.part"} {
+		if _, err := store.Resume(id, "resumed.txt", ".txt", 0); err != service.ErrUploadID {
+			t.Errorf("%q: Expected ErrUploadID, got %v", id, err)
+		}
+	}
+
+	// an interrupted upload keeps what it received
+	writer, err := store.Resume("upload1", "resumed.txt", ".txt", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Resume("upload1", "resumed.txt", ".txt", 0); err != service.ErrUploadBusy {
+		t.Errorf("Expected ErrUploadBusy, got %v", err)
+	}
+
+	if _, err := writer.Write([]byte("hogefu")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Abort(); err != nil {
+		t.Fatal(err)
+	}
+
+	if offset, err := store.UploadOffset("upload1"); err != nil || offset != 6 {
+		t.Errorf("Expected offset 6, got %d (%v)", offset, err)
+	}
+
+	if _, err := store.Resume("upload1", "resumed.txt", ".txt", 7); err != service.ErrUploadOffset {
+		t.Errorf("Expected ErrUploadOffset, got %v", err)
+	}
+
+	// an upload which can't be resumed leaves no partial file behind
+	if _, err := store.Resume("upload2", "other.txt", ".txt", 7); err != service.ErrUploadOffset {
+		t.Errorf("Expected ErrUploadOffset, got %v", err)
+	}
+
+	if _, err := store.UploadOffset("upload2"); err != service.ErrUploadNotFound {
+		t.Errorf("Expected ErrUploadNotFound, got %v", err)
+	}
+
+	// resuming before the offset drops the rest
+	writer, err = store.Resume("upload1", "resumed.txt", ".txt", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writer.Write([]byte("fuga")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the digest covers what was received before the upload was resumed
+	sum := sha256.Sum256([]byte("hogefuga"))
+	if digest := writer.Digest(); digest != hex.EncodeToString(sum[:]) {
+		t.Errorf("Expected the digest of hogefuga, got %s", digest)
+	}
+
+	name, err := writer.Commit(hex.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b, _ := ioutil.ReadFile(filepath.Join(root, name)); name != "resumed.txt" || string(b) != "hogefuga" {
+		t.Errorf("Expected resumed.txt with hogefuga, got %s with %q", name, b)
+	}
+
+	if _, err := store.UploadOffset("upload1"); err != service.ErrUploadNotFound {
+		t.Errorf("Expected a committed upload to be gone, got %v", err)
+	}
+
+	// a corrupted upload is discarded, and can't be resumed
+	writer, err = store.Resume("upload3", "corrupted.txt", ".txt", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writer.Write([]byte("hoge")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writer.Commit(hex.EncodeToString(sum[:])); err != service.ErrFileDigest {
+		t.Errorf("Expected ErrFileDigest, got %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "corrupted.txt")); !os.IsNotExist(err) {
+		t.Error("Expected a corrupted file not to be stored")
+	}
+
+	if _, err := store.UploadOffset("upload3"); err != service.ErrUploadNotFound {
+		t.Errorf("Expected a corrupted upload to be gone, got %v", err)
+	}
+
+	// uploads interrupted for longer than the ttl expire
+	writer, err = store.Resume("upload2", "expired.txt", ".txt", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writer.Write([]byte("hoge")); err != nil {
+		t.Fatal(err)
+	}
+
+	writer.Abort()
+
+	files, err := filepath.Glob(filepath.Join(root, ".vimonade-*.part"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Expected a partial file, got %v (%v)", files, err)
+	}
+
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(files[0], past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	store.ExpireUploads()
+
+	if _, err := store.UploadOffset("upload2"); err != service.ErrUploadNotFound {
+		t.Errorf("Expected an expired upload to be gone, got %v", err)
+	}
+}
+
+func TestOpenFile(t *testing.T) {
+	root := tempDir(t)
+	defer os.RemoveAll(root)
+
+	store, err := service.NewDiskFileStore(root, service.FileStoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(root, "report.txt"), []byte("hogefuga"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, size, err := store.Open("report.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadAll(file)
+	file.Close()
+
+	if err != nil || size != 8 || string(b) != "hogefuga" {
+		t.Errorf("Expected hogefuga of size 8, got %q of size %d (%v)", b, size, err)
+	}
+
+	// only base names are looked up, never sanitized into another file
+	for _, name := range []string{"", "../report.txt", "sub/report.txt", "/etc/passwd", "report.txt."} {
+		if _, _, err := store.Open(name); err != service.ErrFileName {
+			t.Errorf("%q: Expected ErrFileName, got %v", name, err)
+		}
+	}
+
+	// partial uploads and directories aren't stored files
+	writer, err := store.Resume("upload1", "partial.txt", ".txt", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.Abort()
+
+	if err := os.Mkdir(filepath.Join(root, "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"missing.txt", ".vimonade-upload-upload1.part", "partial.txt", "dir"} {
+		if _, _, err := store.Open(name); err != service.ErrFileNotFound {
+			t.Errorf("%q: Expected ErrFileNotFound, got %v", name, err)
+		}
+	}
+
+	// symbolic links aren't followed out of the root
+	if err := os.Symlink("/etc/passwd", filepath.Join(root, "link")); err != nil {
+		t.Skip("cannot create symbolic links: " + err.Error())
+	}
+
+	if _, _, err := store.Open("link"); err != service.ErrFileNotFound {
+		t.Errorf("Expected ErrFileNotFound for a symbolic link, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+func (s *vimonadeServiceServer) ListHistory(ctx context.Context, message *pb.ListHistoryRequest) (*pb.ListHistoryResponse, error) {
+	if err := s.contextError(ctx); err != nil {
+		return &pb.ListHistoryResponse{}, err
+	}
+
+	s.logger.Debug("ListHistory requested")
+
+	entries, err := s.history.List()
+	if err != nil {
+		return &pb.ListHistoryResponse{}, historyError(err)
+	}
+
+	res := &pb.ListHistoryResponse{}
+
+	// a listing only describes the entries, which are read with GetHistory
+	for i, entry := range entries {
+		info := toHistoryEntry(i+1, entry)
+		info.Preview = lemon.Preview(info.GetValue())
+		info.Value, info.Data = "", nil
+
+		res.Entries = append(res.Entries, info)
+	}
+
+	return res, nil
+}
+
+func (s *vimonadeServiceServer) GetHistory(ctx context.Context, message *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	if err := s.contextError(ctx); err != nil {
+		return &pb.GetHistoryResponse{}, err
+	}
+
+	s.logger.Debug(fmt.Sprintf("GetHistory requested: index: %d", message.GetIndex()))
+
+	entry, err := s.history.Find(int(message.GetIndex()))
+	if err != nil {
+		return &pb.GetHistoryResponse{}, historyError(err)
+	}
+
+	if size := len(entry.Value); size > maxPasteSize {
+		return &pb.GetHistoryResponse{}, status.Errorf(codes.ResourceExhausted, "history entry is too large for a single message: %d bytes, paste it with --index", size)
+	}
+
+	return &pb.GetHistoryResponse{Entry: toHistoryEntry(int(message.GetIndex()), entry)}, nil
+}
+
+func (s *vimonadeServiceServer) DeleteHistory(ctx context.Context, message *pb.DeleteHistoryRequest) (*pb.DeleteHistoryResponse, error) {
+	if err := s.contextError(ctx); err != nil {
+		return &pb.DeleteHistoryResponse{}, err
+	}
+
+	if message.GetAll() {
+		s.logger.Debug("DeleteHistory requested: all")
+
+		if err := s.history.Clear(); err != nil {
+			return &pb.DeleteHistoryResponse{}, historyError(err)
+		}
+
+		return &pb.DeleteHistoryResponse{}, nil
+	}
+
+	s.logger.Debug(fmt.Sprintf("DeleteHistory requested: index: %d", message.GetIndex()))
+
+	if err := s.history.Delete(int(message.GetIndex())); err != nil {
+		return &pb.DeleteHistoryResponse{}, historyError(err)
+	}
+
+	return &pb.DeleteHistoryResponse{}, nil
+}
+
+func toHistoryEntry(index int, entry *HistoryEntry) *pb.HistoryEntry {
+	res := &pb.HistoryEntry{
+		Index:     uint32(index),
+		Value:     validText(entry.Value),
+		Register:  entry.Register,
+		Regtype:   entry.Regtype,
+		CreatedAt: entry.CreatedAt.Unix(),
+		Size:      uint64(len(entry.Value)),
+		Origin:    toPbOrigin(entry.Origin),
+	}
+
+	// keep the exact bytes when the value had to be replaced
+	if res.Value != entry.Value {
+		res.Data = []byte(entry.Value)
+	}
+
+	return res
+}
+
+// historyError converts a HistoryStore error to a gRPC status
+func historyError(err error) error {
+	if err == ErrHistoryNotFound {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return logError(status.Errorf(codes.Internal, "cannot access history: %v", err))
+}
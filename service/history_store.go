@@ -0,0 +1,224 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrHistoryNotFound is returned when a history index is out of range
+var ErrHistoryNotFound = errors.New("history entry not found")
+
+// HistoryStore is an interface to store copied entries
+type HistoryStore interface {
+	// Add pushes a new entry on top of the history
+	Add(entry *HistoryEntry) error
+	// List returns all entries, newest first
+	List() ([]*HistoryEntry, error)
+	// Find returns the entry at a 1-based index, 1 being the newest
+	Find(index int) (*HistoryEntry, error)
+	// Delete removes the entry at a 1-based index
+	Delete(index int) error
+	// Clear removes all entries
+	Clear() error
+}
+
+// HistoryEntry contains a copied value and when it was copied
+type HistoryEntry struct {
+	Value     string    `json:"value"`
+	Register  string    `json:"register"`
+	Regtype   string    `json:"regtype"`
+	CreatedAt time.Time `json:"created_at"`
+	// Origin is the client which made the copy
+	Origin *CopyOrigin `json:"origin,omitempty"`
+}
+
+// MarshalJSON saves values which aren't valid UTF-8 as base64 encoded data
+func (e *HistoryEntry) MarshalJSON() ([]byte, error) {
+	type historyEntry HistoryEntry
+
+	value, data := splitText(e.Value)
+
+	return json.Marshal(struct {
+		*historyEntry
+		Value string `json:"value"`
+		Data  []byte `json:"data,omitempty"`
+	}{(*historyEntry)(e), value, data})
+}
+
+// UnmarshalJSON decodes entries saved by MarshalJSON
+func (e *HistoryEntry) UnmarshalJSON(b []byte) error {
+	type historyEntry HistoryEntry
+
+	decoded := struct {
+		*historyEntry
+		Data []byte `json:"data"`
+	}{historyEntry: (*historyEntry)(e)}
+
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+
+	e.Value = joinText(e.Value, decoded.Data)
+
+	return nil
+}
+
+// DiskHistoryStore keeps a bounded history in memory and persists it to a json file
+type DiskHistoryStore struct {
+	mutex   sync.RWMutex
+	path    string
+	limit   int
+	entries []*HistoryEntry
+}
+
+// NewDiskHistoryStore returns a new DiskHistoryStore keeping at most limit entries.
+// A limit of 0 disables the history.
+func NewDiskHistoryStore(path string, limit int) (*DiskHistoryStore, error) {
+	if limit < 0 {
+		limit = 0
+	}
+
+	store := &DiskHistoryStore{
+		path:  path,
+		limit: limit,
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read history: %s", err)
+	}
+
+	if err := json.Unmarshal(b, &store.entries); err != nil {
+		return nil, fmt.Errorf("cannot decode history: %s", err)
+	}
+
+	store.entries = store.truncate(store.entries)
+
+	return store, nil
+}
+
+// Add pushes a new entry, dropping the oldest ones past the limit.
+// Copying the same value twice in a row only keeps the newest entry.
+func (store *DiskHistoryStore) Add(entry *HistoryEntry) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entries := store.entries
+
+	if len(entries) > 0 {
+		last := entries[0]
+		if last.Value == entry.Value && last.Register == entry.Register {
+			entries = entries[1:]
+		}
+	}
+
+	return store.save(store.truncate(append([]*HistoryEntry{entry}, entries...)))
+}
+
+// List returns all entries, newest first
+func (store *DiskHistoryStore) List() ([]*HistoryEntry, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	entries := make([]*HistoryEntry, len(store.entries))
+	copy(entries, store.entries)
+
+	return entries, nil
+}
+
+// Find returns the entry at a 1-based index
+func (store *DiskHistoryStore) Find(index int) (*HistoryEntry, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if index < 1 || index > len(store.entries) {
+		return nil, ErrHistoryNotFound
+	}
+
+	return store.entries[index-1], nil
+}
+
+// Delete removes the entry at a 1-based index
+func (store *DiskHistoryStore) Delete(index int) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if index < 1 || index > len(store.entries) {
+		return ErrHistoryNotFound
+	}
+
+	entries := make([]*HistoryEntry, 0, len(store.entries)-1)
+	entries = append(entries, store.entries[:index-1]...)
+	entries = append(entries, store.entries[index:]...)
+
+	return store.save(entries)
+}
+
+// Clear removes all entries
+func (store *DiskHistoryStore) Clear() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.save(nil)
+}
+
+func (store *DiskHistoryStore) truncate(entries []*HistoryEntry) []*HistoryEntry {
+	if len(entries) > store.limit {
+		return entries[:store.limit]
+	}
+
+	return entries
+}
+
+// save persists entries, which only replace the entries in memory once they're written
+func (store *DiskHistoryStore) save(entries []*HistoryEntry) error {
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("cannot encode history: %s", err)
+	}
+
+	if err := writeFile(store.path, b); err != nil {
+		return fmt.Errorf("cannot write history: %s", err)
+	}
+
+	store.entries = entries
+
+	return nil
+}
+
+// writeFile replaces the file at path with b. b is written to a temporary file
+// renamed over path, so that a crash or a full disk never leaves path truncated.
+func writeFile(path string, b []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	// the temporary file is removed, unless it was renamed to path
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(b); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,63 @@
+package service_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jrc2139/vimonade/service"
+)
+
+func TestDiskHistoryStore(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "state", "history.json")
+
+	if err := os.Mkdir(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := service.NewDiskHistoryStore(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []string{"hoge", "fuga"} {
+		if err := store.Add(&service.HistoryEntry{Value: value}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the history survives a restart, without temporary files left behind
+	store, err = service.NewDiskHistoryStore(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry, err := store.Find(1); err != nil || entry.Value != "fuga" {
+		t.Errorf("Expected: %q, got %v (%v)", "fuga", entry, err)
+	}
+
+	if files, _ := ioutil.ReadDir(filepath.Dir(path)); len(files) != 1 {
+		t.Errorf("Expected only the history file, got %d files", len(files))
+	}
+
+	// a failed save leaves the history as it was
+	if err := os.RemoveAll(filepath.Dir(path)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Add(&service.HistoryEntry{Value: "piyo"}); err == nil {
+		t.Error("Expected an error when the history can't be written")
+	}
+
+	if err := store.Delete(1); err == nil {
+		t.Error("Expected an error when the history can't be written")
+	}
+
+	if entries, _ := store.List(); len(entries) != 2 || entries[0].Value != "fuga" {
+		t.Errorf("Expected the history to be unchanged, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// maxRecentRequests is the number of copy request IDs remembered to ignore copies delivered twice
+const maxRecentRequests = 256
+
+// CopyOrigin describes the client which made a copy
+type CopyOrigin struct {
+	Hostname  string `json:"hostname,omitempty"`
+	User      string `json:"user,omitempty"`
+	Version   string `json:"version,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// String describes the client as user@hostname, followed by its version and the request ID
+func (o *CopyOrigin) String() string {
+	if o == nil {
+		return ""
+	}
+
+	s := o.User + "@" + o.Hostname
+
+	if o.Version != "" {
+		s += " vimonade/" + o.Version
+	}
+
+	if o.RequestID != "" {
+		s += " request: " + o.RequestID
+	}
+
+	return s
+}
+
+// sameClient reports whether two copies were made by the same user on the same host
+func (o *CopyOrigin) sameClient(other *CopyOrigin) bool {
+	if o == nil || other == nil {
+		return false
+	}
+
+	return o.Hostname == other.Hostname && o.User == other.User
+}
+
+func fromPbOrigin(origin *pb.CopyOrigin) *CopyOrigin {
+	if origin == nil {
+		return nil
+	}
+
+	return &CopyOrigin{
+		Hostname:  origin.GetHostname(),
+		User:      origin.GetUser(),
+		Version:   origin.GetVersion(),
+		RequestID: origin.GetRequestId(),
+	}
+}
+
+func toPbOrigin(origin *CopyOrigin) *pb.CopyOrigin {
+	if origin == nil {
+		return nil
+	}
+
+	return &pb.CopyOrigin{
+		Hostname:  origin.Hostname,
+		User:      origin.User,
+		Version:   origin.Version,
+		RequestId: origin.RequestID,
+	}
+}
+
+// copySource describes where a copy comes from in logs: the peer address and the client
+func copySource(ctx context.Context, origin *CopyOrigin) string {
+	if origin == nil {
+		return peerAddr(ctx)
+	}
+
+	return peerAddr(ctx) + " (" + origin.String() + ")"
+}
+
+// recentRequests remembers the responses answered to the latest copy request IDs
+type recentRequests struct {
+	mutex     sync.Mutex
+	responses map[string]*pb.CopyResponse
+	order     []string
+}
+
+func newRecentRequests() *recentRequests {
+	return &recentRequests{responses: make(map[string]*pb.CopyResponse)}
+}
+
+// find returns the response answered to a request ID, if it was already applied
+func (r *recentRequests) find(id string) (*pb.CopyResponse, bool) {
+	if id == "" {
+		return nil, false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	res, ok := r.responses[id]
+
+	return res, ok
+}
+
+// add remembers the response answered to a request ID, forgetting the oldest past maxRecentRequests
+func (r *recentRequests) add(id string, res *pb.CopyResponse) {
+	if id == "" {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.responses[id]; !ok {
+		r.order = append(r.order, id)
+	}
+
+	r.responses[id] = &pb.CopyResponse{Version: res.GetVersion(), Sensitive: res.GetSensitive()}
+
+	if len(r.order) > maxRecentRequests {
+		delete(r.responses, r.order[0])
+		r.order = r.order[1:]
+	}
+}
+
+// isEcho reports whether a copy only brings back the content copied last, as when a clipboard
+// change propagates back to the client which produced it, or through another client syncing it
+func (s *vimonadeServiceServer) isEcho(channel, name, selection string, register *Register, origin *CopyOrigin) bool {
+	if origin == nil {
+		return false
+	}
+
+	var current *Register
+
+	if channel != "" {
+		current = s.channels.Read(channel)
+	} else {
+		// copying the content of an expiring copy again keeps it, which only a write does
+		if lemon.IsClipboardRegister(name) && s.expiring(selection) {
+			return false
+		}
+
+		var err error
+		if current, err = s.readRegister(name, selection); err != nil {
+			return false
+		}
+	}
+
+	return current.Origin != nil &&
+		current.Value == register.Value && withRegtype(current.Value, current.Regtype) == withRegtype(register.Value, register.Regtype)
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// RegisterStore is an interface to store named registers
+type RegisterStore interface {
+	// Save replaces the content of a register
+	Save(name string, register *Register) error
+	// Find returns the content of a register, or an empty register if it was never set
+	Find(name string) (*Register, error)
+}
+
+// Register contains the value of a Vim register and its type
+type Register struct {
+	Value   string `json:"value"`
+	Regtype string `json:"regtype"`
+	// Sensitive copies only live in the clipboard
+	Sensitive bool `json:"-"`
+	// Origin is the client which copied the register
+	Origin *CopyOrigin `json:"origin,omitempty"`
+	// CopiedAt is when the register was copied, or first seen on the host, zero if unknown
+	CopiedAt time.Time `json:"copied_at"`
+}
+
+// MarshalJSON saves values which aren't valid UTF-8 as base64 encoded data
+func (r *Register) MarshalJSON() ([]byte, error) {
+	type register Register
+
+	value, data := splitText(r.Value)
+
+	return json.Marshal(struct {
+		*register
+		Value string `json:"value"`
+		Data  []byte `json:"data,omitempty"`
+	}{(*register)(r), value, data})
+}
+
+// UnmarshalJSON also accepts registers saved as a plain string
+func (r *Register) UnmarshalJSON(b []byte) error {
+	var value string
+	if err := json.Unmarshal(b, &value); err == nil {
+		*r = Register{Value: value}
+		return nil
+	}
+
+	type register Register
+
+	decoded := struct {
+		*register
+		Data []byte `json:"data"`
+	}{register: (*register)(r)}
+
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+
+	r.Value = joinText(r.Value, decoded.Data)
+
+	return nil
+}
+
+// splitText returns text as is if it's valid UTF-8, or as data otherwise.
+// encoding/json would replace the invalid bytes.
+func splitText(text string) (string, []byte) {
+	if utf8.ValidString(text) {
+		return text, nil
+	}
+
+	return "", []byte(text)
+}
+
+// joinText returns the text split by splitText
+func joinText(value string, data []byte) string {
+	if data != nil {
+		return string(data)
+	}
+
+	return value
+}
+
+// DiskRegisterStore keeps registers in memory and persists them to a json file
+type DiskRegisterStore struct {
+	mutex     sync.RWMutex
+	path      string
+	registers map[string]*Register
+}
+
+// NewDiskRegisterStore returns a new DiskRegisterStore loaded from path
+func NewDiskRegisterStore(path string) (*DiskRegisterStore, error) {
+	store := &DiskRegisterStore{
+		path:      path,
+		registers: make(map[string]*Register),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read registers: %s", err)
+	}
+
+	if err := json.Unmarshal(b, &store.registers); err != nil {
+		return nil, fmt.Errorf("cannot decode registers: %s", err)
+	}
+
+	return store, nil
+}
+
+// Save replaces the content of a register and writes all registers to disk
+func (store *DiskRegisterStore) Save(name string, register *Register) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	// the registers in memory only change once they're written
+	registers := make(map[string]*Register, len(store.registers)+1)
+	for other, r := range store.registers {
+		registers[other] = r
+	}
+
+	registers[name] = register
+
+	b, err := json.Marshal(registers)
+	if err != nil {
+		return fmt.Errorf("cannot encode registers: %s", err)
+	}
+
+	if err := writeFile(store.path, b); err != nil {
+		return fmt.Errorf("cannot write registers: %s", err)
+	}
+
+	store.registers = registers
+
+	return nil
+}
+
+// Find returns the content of a register
+func (store *DiskRegisterStore) Find(name string) (*Register, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	register, ok := store.registers[name]
+	if !ok {
+		return &Register{}, nil
+	}
+
+	return register, nil
+}
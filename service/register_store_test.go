@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jrc2139/vimonade/service"
+)
+
+func TestDiskRegisterStore(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "registers.json")
+
+	store, err := service.NewDiskRegisterStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Save("a", &service.Register{Value: "hoge", Regtype: "b4"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// registers survive a restart
+	store, err = service.NewDiskRegisterStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, expected := range map[string]service.Register{
+		"a": {Value: "hoge", Regtype: "b4"},
+		"b": {},
+	} {
+		got, err := store.Find(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if *got != expected {
+			t.Errorf("register %s: Expected: %+v, got %+v", name, expected, *got)
+		}
+	}
+
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("Expected only the registers file, got %d files", len(files))
+	}
+
+	// a failed save leaves the registers as they were
+	store, err = service.NewDiskRegisterStore(filepath.Join(dir, "missing", "registers.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Save("a", &service.Register{Value: "fuga"}); err == nil {
+		t.Error("Expected an error when the registers can't be written")
+	}
+
+	if got, _ := store.Find("a"); got.Value != "" {
+		t.Errorf("Expected register a to be unchanged, got %q", got.Value)
+	}
+}
+
+func TestDiskRegisterStorePlainValues(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "registers.json")
+
+	if err := ioutil.WriteFile(path, []byte(`{"a":"hoge"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := service.NewDiskRegisterStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Find("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Value != "hoge" {
+		t.Errorf("Expected: %q, got %q", "hoge", got.Value)
+	}
+}
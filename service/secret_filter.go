@@ -0,0 +1,173 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// Secret filter actions
+const (
+	SecretOff    = "off"
+	SecretWarn   = "warn"
+	SecretRedact = "redact"
+	SecretBlock  = "block"
+)
+
+// redacted replaces the secrets of a redacted copy
+const redacted = "[REDACTED]"
+
+// ErrSecretBlocked is returned when a copy containing a secret is blocked
+var ErrSecretBlocked = errors.New("copy blocked because it contains a secret")
+
+// SecretRule matches a kind of secret
+type SecretRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// DefaultSecretRules match AWS keys, PEM private keys and JWTs
+var DefaultSecretRules = []*SecretRule{
+	{Name: "aws-access-key-id", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{Name: "aws-secret-access-key", Pattern: regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}\b`)},
+	{Name: "private-key", Pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----(?s:.*?)-----END (?:[A-Z0-9]+ )*PRIVATE KEY-----`)},
+	{Name: "jwt", Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)},
+}
+
+// SecretFilter scans copied text for secrets, and warns about, redacts or blocks them
+type SecretFilter struct {
+	action string
+	rules  []*SecretRule
+}
+
+// NewSecretFilter returns a new SecretFilter applying action to the matches of rules
+func NewSecretFilter(action string, rules []*SecretRule) (*SecretFilter, error) {
+	switch action {
+	case SecretOff, SecretWarn, SecretRedact, SecretBlock:
+	default:
+		return nil, fmt.Errorf("unknown secret filter action: %s", action)
+	}
+
+	return &SecretFilter{action: action, rules: rules}, nil
+}
+
+// LoadSecretRules returns the built-in rules and the ones of a json file mapping names to regular expressions
+func LoadSecretRules(path string) ([]*SecretRule, error) {
+	rules := append([]*SecretRule{}, DefaultSecretRules...)
+
+	if path == "" {
+		return rules, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read secret rules: %s", err)
+	}
+
+	var patterns map[string]string
+	if err := json.Unmarshal(b, &patterns); err != nil {
+		return nil, fmt.Errorf("cannot decode secret rules: %s", err)
+	}
+
+	names := make([]string, 0, len(patterns))
+	for name := range patterns {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		pattern, err := regexp.Compile(patterns[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret rule %s: %s", name, err)
+		}
+
+		rules = append(rules, &SecretRule{Name: name, Pattern: pattern})
+	}
+
+	return rules, nil
+}
+
+// Action returns what the filter does with secrets
+func (f *SecretFilter) Action() string {
+	if f == nil {
+		return SecretOff
+	}
+
+	return f.action
+}
+
+// Filter returns text with its secrets redacted if needed and the names of the rules it matched.
+// ErrSecretBlocked is returned when blocking a text containing a secret.
+func (f *SecretFilter) Filter(text string) (string, []string, error) {
+	if f == nil || f.action == SecretOff {
+		return text, nil, nil
+	}
+
+	var matched []string
+
+	for _, rule := range f.rules {
+		if !rule.Pattern.MatchString(text) {
+			continue
+		}
+
+		matched = append(matched, rule.Name)
+
+		if f.action == SecretRedact {
+			text = rule.Pattern.ReplaceAllLiteralString(text, redacted)
+		}
+	}
+
+	if len(matched) > 0 && f.action == SecretBlock {
+		return "", matched, ErrSecretBlocked
+	}
+
+	return text, matched, nil
+}
+
+// filterPayloads runs the text payloads of a copy through the filter
+func (f *SecretFilter) filterPayloads(payloads []*Payload) ([]*Payload, []string, error) {
+	var matched []string
+
+	filtered := make([]*Payload, len(payloads))
+
+	for i, payload := range payloads {
+		filtered[i] = payload
+
+		if !strings.HasPrefix(lemon.MediaType(payload.MimeType), "text/") {
+			continue
+		}
+
+		text, names, err := f.Filter(string(payload.Data))
+		matched = append(matched, names...)
+
+		if err != nil {
+			return nil, dedupe(matched), err
+		}
+
+		filtered[i] = &Payload{MimeType: payload.MimeType, Data: []byte(text)}
+	}
+
+	return filtered, dedupe(matched), nil
+}
+
+func dedupe(names []string) []string {
+	seen := make(map[string]bool)
+
+	var unique []string
+
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+
+	return unique
+}
@@ -1,35 +1,85 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
-	"github.com/atotto/clipboard"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/jrc2139/vimonade/api"
-)
-
-const (
-	maxFileSize = 1 << 30
+	"github.com/jrc2139/vimonade/lemon"
 )
 
 // VimonadeServer is implementation of pb.VimonadeServer proto interface.
 type vimonadeServiceServer struct {
 	// localStore LocalStore
 	fileStore  FileStore
+	clipboard  Clipboard
+	registers  RegisterStore
+	history    HistoryStore
+	snippets   SnippetStore
+	watcher    *Watcher
+	channels   *Channels
+	requests   *recentRequests
+	secrets    *SecretFilter
+	transforms *Transforms
 	lineEnding string
 	// path       string
 	logger *zap.Logger
+
+	// mutex protects the clipboard writes, lastCopy and expiries
+	mutex sync.Mutex
+	// lastCopy holds the last register copied to each selection
+	lastCopy map[string]*Register
+	// expiries holds the pending expiry of each selection
+	expiries map[string]*expiry
+}
+
+// ServiceConfig contains the stores and options used to build the service
+type ServiceConfig struct {
+	FileStore FileStore
+	Clipboard Clipboard
+	Registers RegisterStore
+	History   HistoryStore
+	Snippets  SnippetStore
+	Watcher   *Watcher
+	// Channels are the shared clipboards, with none bridged when nil
+	Channels   *Channels
+	Secrets    *SecretFilter
+	Transforms *Transforms
+	LineEnding string
 }
 
 // NewVimonadeServerService creates Audio service object.
-func NewVimonadeServerService(fileStore FileStore, lineEnding string, logger *zap.Logger) pb.VimonadeServiceServer {
-	return &vimonadeServiceServer{fileStore: fileStore, lineEnding: lineEnding, logger: logger}
+func NewVimonadeServerService(config ServiceConfig, logger *zap.Logger) pb.VimonadeServiceServer {
+	channels := config.Channels
+	if channels == nil {
+		channels = NewChannels(nil)
+	}
+
+	return &vimonadeServiceServer{
+		fileStore:  config.FileStore,
+		clipboard:  config.Clipboard,
+		registers:  config.Registers,
+		history:    config.History,
+		snippets:   config.Snippets,
+		watcher:    config.Watcher,
+		channels:   channels,
+		requests:   newRecentRequests(),
+		secrets:    config.Secrets,
+		transforms: config.Transforms,
+		lineEnding: config.LineEnding,
+		logger:     logger,
+		lastCopy:   make(map[string]*Register),
+		expiries:   make(map[string]*expiry),
+	}
 }
 
 func (s *vimonadeServiceServer) Send(stream pb.VimonadeService_SendServer) error {
@@ -40,11 +90,31 @@ func (s *vimonadeServiceServer) Send(stream pb.VimonadeService_SendServer) error
 
 	name := req.GetInfo().GetName()
 	fileType := req.GetInfo().GetFileType()
+	uploadID := req.GetInfo().GetUploadId()
+	digest := req.GetInfo().GetSha256()
 
 	s.logger.Info("receive an send-file request for " + name)
 
-	fData := bytes.Buffer{}
-	fSize := 0
+	// only a resumed upload starts past the beginning of the file
+	if uploadID == "" && req.GetInfo().GetOffset() != 0 {
+		return logError(status.Errorf(codes.InvalidArgument, "an offset needs an upload id"))
+	}
+
+	var writer FileWriter
+	if uploadID != "" {
+		s.logger.Info(fmt.Sprintf("resume upload %s at offset %d", uploadID, req.GetInfo().GetOffset()))
+		writer, err = s.fileStore.Resume(uploadID, name, fileType, int64(req.GetInfo().GetOffset()))
+	} else {
+		writer, err = s.fileStore.Create(name, fileType)
+	}
+	if err != nil {
+		return logError(fileError(err))
+	}
+	// the partial file is removed on cancel or error, unless the upload is resumable
+	defer writer.Abort()
+
+	// the size includes what was received before the upload was resumed
+	fSize := int64(req.GetInfo().GetOffset())
 
 	for {
 		s.logger.Debug("waiting to receive more data")
@@ -68,24 +138,24 @@ func (s *vimonadeServiceServer) Send(stream pb.VimonadeService_SendServer) error
 
 		s.logger.Debug(fmt.Sprintf("received a chunk with size %d", size))
 
-		fSize += size
-		if fSize > maxFileSize {
-			return logError(status.Errorf(codes.InvalidArgument, "f is too large: %d > %d", fSize, maxFileSize))
-		}
-		_, err = fData.Write(chunk)
-		if err != nil {
-			return logError(status.Errorf(codes.Internal, "cannot write chunk data: %v", err))
+		fSize += int64(size)
+
+		if _, err := writer.Write(chunk); err != nil {
+			return logError(fileError(err))
 		}
 	}
 
-	savedName, err := s.fileStore.Save(name, fileType, fData)
+	sum := writer.Digest()
+
+	savedName, err := writer.Commit(digest)
 	if err != nil {
-		return logError(status.Errorf(codes.Internal, "cannot save file to the store: %v", err))
+		return logError(fileError(err))
 	}
 
 	res := &pb.SendFileResponse{
-		Name: savedName,
-		Size: uint32(fSize),
+		Name:   savedName,
+		Size:   uint64(fSize),
+		Sha256: sum,
 	}
 
 	err = stream.SendAndClose(res)
@@ -93,7 +163,7 @@ func (s *vimonadeServiceServer) Send(stream pb.VimonadeService_SendServer) error
 		return logError(status.Errorf(codes.Unknown, "cannot send response: %v", err))
 	}
 
-	s.logger.Debug(fmt.Sprintf("saved file %s with size %d", name, fSize))
+	s.logger.Debug(fmt.Sprintf("saved file %s with size %d, sha256: %s", savedName, fSize, sum))
 
 	return nil
 }
@@ -104,21 +174,157 @@ func (s *vimonadeServiceServer) Copy(ctx context.Context, message *pb.CopyReques
 		return &pb.CopyResponse{}, err
 	}
 
+	res := &pb.CopyResponse{}
+
 	if message != nil {
-		s.logger.Debug("Copy requested: message: " + message.GetValue())
+		regtype, err := lemon.NormalizeRegtype(message.GetRegtype())
+		if err != nil {
+			return &pb.CopyResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		selection, err := lemon.NormalizeSelection(message.GetSelection())
+		if err != nil {
+			return &pb.CopyResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		channel, err := s.channels.Resolve(message.GetChannel())
+		if err != nil {
+			return &pb.CopyResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		origin := fromPbOrigin(message.GetOrigin())
+
+		// a copy retried or relayed by the client is only applied once
+		if applied, ok := s.requests.find(message.GetOrigin().GetRequestId()); ok {
+			s.logger.Debug("Copy already applied: origin: " + copySource(ctx, origin))
+			return applied, nil
+		}
+
+		payloads, err := s.transforms.copy(fromPbPayloads(message.GetValue(), message.GetPayloads()))
+		if err != nil {
+			return &pb.CopyResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		// secrets are filtered before reaching the clipboard, logs or history
+		payloads, secrets, err := s.secrets.filterPayloads(payloads)
+		if err != nil {
+			s.logger.Warn("Copy blocked: secrets: " + strings.Join(secrets, ", ") + " origin: " + copySource(ctx, origin))
+			return &pb.CopyResponse{}, status.Errorf(codes.PermissionDenied, "%v: %s", err, strings.Join(secrets, ", "))
+		}
+
+		if len(secrets) > 0 {
+			s.logger.Warn("Copy contains secrets: " + strings.Join(secrets, ", ") + " origin: " + copySource(ctx, origin))
+		}
+
+		text, _ := splitPayloads(payloads)
+
+		// secrets only warned about are kept out of history, watchers and client caches like
+		// sensitive copies, without expiring
+		warned := len(secrets) > 0 && s.secrets.Action() == SecretWarn
+		sensitive := message.GetSensitive() || warned
+
+		// clients mustn't cache a sensitive copy
+		res.Sensitive = sensitive
+
+		switch {
+		case message.GetSensitive():
+			s.logger.Debug("Copy requested: register: " + message.GetRegister() + " origin: " + copySource(ctx, origin) + " message: <sensitive>")
+		case warned:
+			s.logger.Debug("Copy requested: register: " + message.GetRegister() + " origin: " + copySource(ctx, origin) + " message: <secret>")
+		default:
+			s.logger.Debug("Copy requested: register: " + message.GetRegister() + " origin: " + copySource(ctx, origin) + " message: " + text)
+		}
+
+		for _, payload := range payloads {
+			s.logger.Debug(fmt.Sprintf("Copy requested: format: %s size: %d", payload.MimeType, len(payload.Data)))
+		}
+
+		register := &Register{
+			Value:     text,
+			Regtype:   regtype,
+			Sensitive: sensitive,
+			Origin:    origin,
+			CopiedAt:  time.Now(),
+		}
+
+		ttl := copyTTL(message.GetSensitive(), message.GetTtl())
+
+		// a clipboard change coming back from a client isn't a change, and would bounce between them
+		if _, other := splitPayloads(payloads); len(other) == 0 && ttl == 0 && s.isEcho(channel, message.GetRegister(), selection, register, origin) {
+			s.logger.Debug("Copy echo suppressed: origin: " + copySource(ctx, origin))
+
+			res.Version = s.copyVersion(register)
+			s.requests.add(origin.RequestID, res)
+
+			return res, nil
+		}
+
+		if channel != "" {
+			err = s.writeChannel(channel, message.GetRegister(), selection, register, payloads, ttl)
+		} else {
+			err = s.writeRegister(message.GetRegister(), selection, register, payloads, ttl)
+		}
 
-		if err := clipboard.WriteAll(message.GetValue()); err != nil {
-			s.logger.Fatal("Writing to clipboard failed: " + err.Error())
-			return &pb.CopyResponse{}, err
+		if err != nil {
+			s.logger.Error("Writing to clipboard failed: " + err.Error())
+			return &pb.CopyResponse{}, clipboardError(err)
+		}
+
+		res.Version = s.copyVersion(register)
+		s.requests.add(message.GetOrigin().GetRequestId(), res)
+
+		// watchers follow the clipboard selection only
+		if selection == lemon.SelectionClipboard {
+			s.watcher.Publish(&Event{
+				Register:  message.GetRegister(),
+				Value:     text,
+				Regtype:   regtype,
+				Origin:    peerAddr(ctx),
+				Time:      time.Now(),
+				Sensitive: sensitive,
+				Channel:   channel,
+				Client:    origin,
+			})
+		}
+
+		// history only keeps text of the clipboard selection of the system clipboard,
+		// and never sensitive copies
+		if channel == "" && selection == lemon.SelectionClipboard && !sensitive && (text != "" || len(message.GetPayloads()) == 0) {
+			if err := s.history.Add(&HistoryEntry{
+				Value:     text,
+				Register:  message.GetRegister(),
+				Regtype:   regtype,
+				CreatedAt: time.Now(),
+				Origin:    origin,
+			}); err != nil {
+				s.logger.Error("Adding to history failed: " + err.Error())
+			}
 		}
 	} else {
 		s.logger.Debug("Copy requested: message=<empty>")
 	}
 
-	return &pb.CopyResponse{}, err
+	return res, err
 }
 
+// Paste answers the content of a register, or only that it's unchanged
+// when it still matches the version the client already has.
+// Content too large for a single message is only answered by PasteStream.
 func (s *vimonadeServiceServer) Paste(ctx context.Context, message *pb.PasteRequest) (*pb.PasteResponse, error) {
+	res, err := s.pasteVersioned(ctx, message)
+	if err != nil {
+		return res, err
+	}
+
+	if size := pasteSize(res); size > maxPasteSize {
+		return &pb.PasteResponse{}, status.Errorf(codes.ResourceExhausted, "paste is too large for a single message: %d bytes, use PasteStream", size)
+	}
+
+	return res, nil
+}
+
+// pasteVersioned answers a paste, or only that it's unchanged
+func (s *vimonadeServiceServer) pasteVersioned(ctx context.Context, message *pb.PasteRequest) (*pb.PasteResponse, error) {
 	err := s.contextError(ctx)
 	if err != nil {
 		return &pb.PasteResponse{}, err
@@ -126,17 +332,341 @@ func (s *vimonadeServiceServer) Paste(ctx context.Context, message *pb.PasteRequ
 
 	if message != nil {
 		s.logger.Debug("Paste requested: message: " + message.GetValue())
+	} else {
+		s.logger.Debug("Paste requested: message=<empty>")
+	}
+
+	res, err := s.paste(message)
+	if err != nil {
+		return res, err
+	}
+
+	res.Version = pasteVersion(res)
+
+	if version := message.GetIfNoneMatch(); version != "" && version == res.Version {
+		s.logger.Debug("Paste unchanged: version: " + version)
+
+		return &pb.PasteResponse{
+			Regtype:   res.GetRegtype(),
+			Version:   res.GetVersion(),
+			Unchanged: true,
+			Sensitive: res.GetSensitive(),
+			CopiedAt:  res.GetCopiedAt(),
+		}, nil
+	}
+
+	return res, nil
+}
+
+func (s *vimonadeServiceServer) paste(message *pb.PasteRequest) (*pb.PasteResponse, error) {
+	channel, err := s.channels.Resolve(message.GetChannel())
+	if err != nil {
+		return &pb.PasteResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if channel != "" {
+		return s.pasteChannel(channel, message)
+	}
+
+	if index := message.GetIndex(); index > 0 {
+		if selection, err := lemon.NormalizeSelection(message.GetSelection()); err != nil || selection != lemon.SelectionClipboard {
+			return &pb.PasteResponse{}, status.Error(codes.InvalidArgument, ErrHistorySelection.Error())
+		}
+
+		entry, err := s.history.Find(int(index))
+		if err != nil {
+			return &pb.PasteResponse{}, historyError(err)
+		}
+
+		text, err := s.transforms.paste(entry.Value)
+		if err != nil {
+			return &pb.PasteResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		res := toPasteResponse(text, withRegtype(text, entry.Regtype))
+		res.CopiedAt = unixMillis(entry.CreatedAt)
+
+		return res, nil
+	}
+
+	selection, err := lemon.NormalizeSelection(message.GetSelection())
+	if err != nil {
+		return &pb.PasteResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
 
-		_, err := clipboard.ReadAll()
+	if mimeType := message.GetMimeType(); !lemon.IsText(mimeType) {
+		data, err := s.readFormat(message.GetRegister(), selection, mimeType)
 		if err != nil {
-			s.logger.Fatal("Reading from clipboard failed: " + err.Error())
-			return &pb.PasteResponse{}, err
+			s.logger.Debug("Reading " + mimeType + " from clipboard failed: " + err.Error())
+			return &pb.PasteResponse{}, clipboardError(err)
 		}
+
+		return &pb.PasteResponse{Payloads: []*pb.Payload{{MimeType: lemon.MediaType(mimeType), Data: data}}}, nil
+	}
+
+	register, err := s.readRegister(message.GetRegister(), selection)
+	if err != nil {
+		s.logger.Error("Reading from clipboard failed: " + err.Error())
+		return &pb.PasteResponse{}, clipboardError(err)
+	}
+
+	text, err := s.transforms.paste(register.Value)
+	if err != nil {
+		return &pb.PasteResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res := toPasteResponse(text, withRegtype(text, register.Regtype))
+	res.Sensitive = register.Sensitive
+	res.CopiedAt = unixMillis(register.CopiedAt)
+
+	return res, nil
+}
+
+// copyVersion returns the version a Paste of a copied register answers
+func (s *vimonadeServiceServer) copyVersion(register *Register) string {
+	text, err := s.transforms.paste(register.Value)
+	if err != nil {
+		return ""
+	}
+
+	return pasteVersion(toPasteResponse(text, withRegtype(text, register.Regtype)))
+}
+
+// pasteVersion returns the version of the content of a paste
+func pasteVersion(res *pb.PasteResponse) string {
+	if payloads := res.GetPayloads(); len(payloads) > 0 {
+		return lemon.ContentVersion(res.GetRegtype(), payloads[0].GetMimeType(), payloads[0].GetData())
+	}
+
+	return lemon.ContentVersion(res.GetRegtype(), "", []byte(res.GetValue()))
+}
+
+// toPasteResponse returns pasted text, as a plain text payload if it isn't valid UTF-8
+// since proto strings can't hold it
+func toPasteResponse(text, regtype string) *pb.PasteResponse {
+	res := &pb.PasteResponse{Regtype: withRegtype(text, regtype)}
+
+	if utf8.ValidString(text) {
+		res.Value = text
 	} else {
-		s.logger.Debug("Paste requested: message=<empty>")
+		res.Payloads = []*pb.Payload{{MimeType: lemon.TextMimeType, Data: []byte(text)}}
+	}
+
+	return res
+}
+
+// validText returns text with its invalid UTF-8 bytes replaced by U+FFFD
+func validText(text string) string {
+	if utf8.ValidString(text) {
+		return text
+	}
+
+	return strings.Map(func(r rune) rune { return r }, text)
+}
+
+// writeRegister stores a register in a selection of the system clipboard or in a named register.
+// The system clipboard can't hold the register type, so it's kept with the last copy.
+// Other formats than plain text are only written to the clipboard selection.
+// A ttl above 0 restores the previous content of the selection once it passes.
+func (s *vimonadeServiceServer) writeRegister(name, selection string, register *Register, payloads []*Payload, ttl time.Duration) error {
+	_, other := splitPayloads(payloads)
+
+	if !lemon.IsClipboardRegister(name) {
+		if len(other) > 0 {
+			return ErrRegisterFormat
+		}
+
+		if selection != lemon.SelectionClipboard {
+			return ErrRegisterSelection
+		}
+
+		if ttl > 0 {
+			return ErrRegisterTTL
+		}
+
+		return s.registers.Save(name, register)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var previous *Register
+	if ttl > 0 {
+		previous = s.previousRegister(selection)
+	}
+
+	if len(other) > 0 {
+		if selection != lemon.SelectionClipboard {
+			return ErrSelectionFormat
+		}
+
+		cb, ok := s.clipboard.(FormatClipboard)
+		if !ok {
+			return ErrFormatUnsupported
+		}
+
+		if err := cb.WriteFormats(payloads); err != nil {
+			return err
+		}
+	} else if err := s.writeSelection(selection, register.Value); err != nil {
+		return err
+	}
+
+	s.cancelExpiry(selection)
+	s.lastCopy[selection] = register
+
+	if ttl > 0 {
+		s.expireAfter(selection, register, previous, ttl)
+	}
+
+	return nil
+}
+
+// readRegister returns a selection of the system clipboard or the content of a named register
+func (s *vimonadeServiceServer) readRegister(name, selection string) (*Register, error) {
+	if !lemon.IsClipboardRegister(name) {
+		if selection != lemon.SelectionClipboard {
+			return nil, ErrRegisterSelection
+		}
+
+		return s.registers.Find(name)
+	}
+
+	text, err := s.readSelection(selection)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the clipboard may have been changed by another application since
+	if last, ok := s.lastCopy[selection]; ok && last.Value == text {
+		return last, nil
+	}
+
+	// a change made on the host is dated when the watcher polling the clipboard saw it
+	register := &Register{Value: text}
+	if selection == lemon.SelectionClipboard {
+		register.CopiedAt = s.watcher.SeenAt(text)
+	}
+
+	return register, nil
+}
+
+// writeSelection replaces the text of a selection of the system clipboard
+func (s *vimonadeServiceServer) writeSelection(selection, text string) error {
+	if selection == lemon.SelectionClipboard {
+		return s.clipboard.Write(text)
+	}
+
+	cb, ok := s.clipboard.(SelectionClipboard)
+	if !ok {
+		return ErrSelectionUnsupported
+	}
+
+	return cb.WriteSelection(selection, text)
+}
+
+// readSelection returns the text of a selection of the system clipboard
+func (s *vimonadeServiceServer) readSelection(selection string) (string, error) {
+	if selection == lemon.SelectionClipboard {
+		return s.clipboard.Read()
+	}
+
+	cb, ok := s.clipboard.(SelectionClipboard)
+	if !ok {
+		return "", ErrSelectionUnsupported
+	}
+
+	return cb.ReadSelection(selection)
+}
+
+// readFormat returns the system clipboard in another format than plain text
+func (s *vimonadeServiceServer) readFormat(name, selection, mimeType string) ([]byte, error) {
+	if !lemon.IsClipboardRegister(name) {
+		return nil, ErrRegisterFormat
+	}
+
+	if selection != lemon.SelectionClipboard {
+		return nil, ErrSelectionFormat
+	}
+
+	cb, ok := s.clipboard.(FormatClipboard)
+	if !ok {
+		return nil, ErrFormatUnsupported
+	}
+
+	return cb.ReadFormat(mimeType)
+}
+
+// fromPbPayloads returns the payloads of a copy, value being its plain text
+func fromPbPayloads(value string, payloads []*pb.Payload) []*Payload {
+	var converted []*Payload
+
+	if value != "" || len(payloads) == 0 {
+		converted = append(converted, &Payload{MimeType: lemon.TextMimeType, Data: []byte(value)})
+	}
+
+	for _, payload := range payloads {
+		converted = append(converted, &Payload{MimeType: payload.GetMimeType(), Data: payload.GetData()})
+	}
+
+	return converted
+}
+
+// clipboardError converts a Clipboard error to a gRPC status
+func clipboardError(err error) error {
+	switch err {
+	case ErrFormatNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case ErrFormatUnsupported, ErrSingleFormat, ErrSelectionUnsupported:
+		return status.Error(codes.Unimplemented, err.Error())
+	case ErrRegisterFormat, ErrRegisterSelection, ErrSelectionFormat, ErrRegisterTTL, ErrChannelClipboard, ErrChannelTTL:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case ErrChannelLimit, ErrChannelSize:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "cannot access clipboard: %v", err)
+	}
+}
+
+// unixMillis returns t in milliseconds since the unix epoch, 0 for the zero time
+func unixMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// fileError converts a FileStore error to a gRPC status
+func fileError(err error) error {
+	switch err {
+	case ErrFileName, ErrFileTooLarge, ErrUploadID:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case ErrFileExists:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case ErrUploadNotFound, ErrFileNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case ErrUploadOffset:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case ErrUploadBusy:
+		return status.Error(codes.Aborted, err.Error())
+	case ErrFileDigest:
+		return status.Error(codes.DataLoss, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "cannot access the file store: %v", err)
+	}
+}
+
+// withRegtype returns regtype, or the register type inferred from text if it's unknown
+func withRegtype(text, regtype string) string {
+	if regtype == "" {
+		return lemon.InferRegtype(text)
 	}
 
-	return &pb.PasteResponse{}, nil
+	return regtype
 }
 
 func logError(err error) error {
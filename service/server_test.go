@@ -1,7 +1,20 @@
 package service_test
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+	"github.com/jrc2139/vimonade/service"
 )
 
 func TestServer(t *testing.T) {
@@ -79,3 +92,673 @@ func TestServer(t *testing.T) {
 	//     })
 	/*  } */
 }
+
+func newTestServer(t *testing.T, dir string, cb service.Clipboard) pb.VimonadeServiceServer {
+	registers, err := service.NewDiskRegisterStore(filepath.Join(dir, "registers.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := service.NewDiskHistoryStore(filepath.Join(dir, "history.json"), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snippets, err := service.NewDiskSnippetStore(filepath.Join(dir, "snippets.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher := service.NewWatcher(cb, 0, zap.NewNop())
+
+	return service.NewVimonadeServerService(service.ServiceConfig{Clipboard: cb, Registers: registers, History: history, Snippets: snippets, Watcher: watcher, Channels: service.NewChannels([]string{"bridged"})}, zap.NewNop())
+}
+
+func TestCopyPasteRegisters(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	cb := service.NewMemoryClipboard()
+	server := newTestServer(t, dir, cb)
+
+	ctx := context.Background()
+
+	for _, req := range []*pb.CopyRequest{
+		{Value: "clipboard"},
+		{Value: "named", Register: "a"},
+	} {
+		if _, err := server.Copy(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if text, _ := cb.Read(); text != "clipboard" {
+		t.Errorf("Expected the system clipboard to hold %q, got %q", "clipboard", text)
+	}
+
+	for register, expected := range map[string]string{"": "clipboard", "+": "clipboard", "a": "named", "b": ""} {
+		res, err := server.Paste(ctx, &pb.PasteRequest{Register: register})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.GetValue() != expected {
+			t.Errorf("register %q: Expected: %q, got %q", register, expected, res.GetValue())
+		}
+	}
+}
+
+func TestCopyPasteRegtype(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	cb := service.NewMemoryClipboard()
+	server := newTestServer(t, dir, cb)
+	ctx := context.Background()
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "ab\ncd", Regtype: "\x162"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "a", Register: "a", Regtype: "V"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "a", Regtype: "x"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown regtype, got %v", err)
+	}
+
+	assert := func(req *pb.PasteRequest, expected string) {
+		res, err := server.Paste(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.GetRegtype() != expected {
+			t.Errorf("%v: Expected regtype %q, got %q", req, expected, res.GetRegtype())
+		}
+	}
+
+	assert(&pb.PasteRequest{}, "b2")
+	assert(&pb.PasteRequest{Register: "a"}, "V")
+	assert(&pb.PasteRequest{Index: 1}, "V")
+	assert(&pb.PasteRequest{Index: 2}, "b2")
+
+	// another application changed the clipboard
+	if err := cb.Write("ef\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert(&pb.PasteRequest{}, "V")
+}
+
+func TestCopyPasteFormats(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	server := newTestServer(t, dir, service.NewMemoryClipboard())
+	ctx := context.Background()
+
+	html := &pb.Payload{MimeType: "text/html", Data: []byte("<b>hoge</b>")}
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "hoge", Payloads: []*pb.Payload{html}}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := server.Paste(ctx, &pb.PasteRequest{MimeType: "text/html"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.GetPayloads()) != 1 || string(res.GetPayloads()[0].GetData()) != "<b>hoge</b>" {
+		t.Errorf("Expected the html payload, got %v", res.GetPayloads())
+	}
+
+	if res, _ := server.Paste(ctx, &pb.PasteRequest{}); res.GetValue() != "hoge" {
+		t.Errorf("Expected: %q, got %q", "hoge", res.GetValue())
+	}
+
+	if _, err := server.Paste(ctx, &pb.PasteRequest{MimeType: "image/png"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	_, err = server.Copy(ctx, &pb.CopyRequest{Register: "a", Payloads: []*pb.Payload{html}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a named register, got %v", err)
+	}
+}
+
+func TestCopyPasteSelections(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	server := newTestServer(t, dir, service.NewMemoryClipboard())
+	ctx := context.Background()
+
+	for _, req := range []*pb.CopyRequest{
+		{Value: "clipboard"},
+		{Value: "primary\n", Selection: "primary"},
+	} {
+		if _, err := server.Copy(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		req      *pb.PasteRequest
+		expected string
+	}{
+		{&pb.PasteRequest{}, "clipboard"},
+		{&pb.PasteRequest{Selection: "clipboard"}, "clipboard"},
+		{&pb.PasteRequest{Selection: "PRIMARY"}, "primary\n"},
+		{&pb.PasteRequest{Selection: "secondary"}, ""},
+	} {
+		res, err := server.Paste(ctx, tc.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.GetValue() != tc.expected {
+			t.Errorf("%v: Expected: %q, got %q", tc.req, tc.expected, res.GetValue())
+		}
+	}
+
+	// history only keeps copies of the clipboard selection
+	history, err := server.ListHistory(ctx, &pb.ListHistoryRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entries := history.GetEntries(); len(entries) != 1 || entries[0].GetPreview() != "clipboard" {
+		t.Errorf("Expected only the clipboard copy in history, got %v", entries)
+	}
+
+	if _, err := server.Paste(ctx, &pb.PasteRequest{Index: 1, Selection: "primary"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a history entry of primary, got %v", err)
+	}
+
+	for _, req := range []*pb.CopyRequest{
+		{Value: "hoge", Selection: "tertiary"},
+		{Value: "hoge", Register: "a", Selection: "primary"},
+		{Selection: "primary", Payloads: []*pb.Payload{{MimeType: "image/png", Data: []byte{0x89}}}},
+	} {
+		if _, err := server.Copy(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: Expected InvalidArgument, got %v", req, err)
+		}
+	}
+}
+
+func TestCopyPasteChannels(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	cb := service.NewMemoryClipboard()
+	server := newTestServer(t, dir, cb)
+	ctx := context.Background()
+
+	for _, req := range []*pb.CopyRequest{
+		{Value: "clipboard"},
+		{Value: "pair", Channel: "Pair"},
+		{Value: "review", Channel: "review"},
+		{Value: "bridged", Channel: "bridged"},
+	} {
+		if _, err := server.Copy(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		req      *pb.PasteRequest
+		expected string
+	}{
+		{&pb.PasteRequest{Channel: "pair"}, "pair"},
+		{&pb.PasteRequest{Channel: "review"}, "review"},
+		{&pb.PasteRequest{Channel: "empty"}, ""},
+		// a bridged channel is the system clipboard
+		{&pb.PasteRequest{Channel: "bridged"}, "bridged"},
+		{&pb.PasteRequest{}, "bridged"},
+	} {
+		res, err := server.Paste(ctx, tc.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.GetValue() != tc.expected {
+			t.Errorf("%v: Expected: %q, got %q", tc.req, tc.expected, res.GetValue())
+		}
+	}
+
+	if text, _ := cb.Read(); text != "bridged" {
+		t.Errorf("Expected channels to leave the clipboard alone, got %q", text)
+	}
+
+	for _, req := range []*pb.CopyRequest{
+		{Value: "hoge", Channel: "a b"},
+		{Value: "hoge", Channel: "pair", Register: "a"},
+		{Value: "hoge", Channel: "pair", Selection: "primary"},
+		{Value: "hoge", Channel: "pair", Sensitive: true},
+		{Channel: "pair", Payloads: []*pb.Payload{{MimeType: "image/png", Data: []byte{0x89}}}},
+	} {
+		if _, err := server.Copy(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: Expected InvalidArgument, got %v", req, err)
+		}
+	}
+
+	if _, err := server.Paste(ctx, &pb.PasteRequest{Channel: "pair", Index: 1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestCopyOrigin(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	server := newTestServer(t, dir, service.NewMemoryClipboard())
+	ctx := context.Background()
+
+	alice := func(id string) *pb.CopyOrigin {
+		return &pb.CopyOrigin{Hostname: "laptop", User: "alice", Version: "1.0", RequestId: id}
+	}
+	bob := &pb.CopyOrigin{Hostname: "desktop", User: "bob", RequestId: "b1"}
+
+	for _, req := range []*pb.CopyRequest{
+		{Value: "hoge", Origin: alice("a1")},
+		// a copy delivered twice is only applied once
+		{Value: "fuga", Origin: alice("a1")},
+		// the copy coming back from another client is an echo
+		{Value: "hoge", Origin: bob},
+	} {
+		if _, err := server.Copy(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := server.Paste(ctx, &pb.PasteRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.GetValue() != "hoge" {
+		t.Errorf("Expected: %q, got %q", "hoge", res.GetValue())
+	}
+
+	history, err := server.ListHistory(ctx, &pb.ListHistoryRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entries := history.GetEntries(); len(entries) != 1 || entries[0].GetOrigin().GetRequestId() != "a1" {
+		t.Errorf("Expected only the first copy in history, got %v", entries)
+	}
+
+	// the change coming back to the client which produced it is an echo too
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "hoge", Origin: alice("a2")}); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err = server.ListHistory(ctx, &pb.ListHistoryRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entries := history.GetEntries(); len(entries) != 1 {
+		t.Errorf("Expected no new history entry, got %v", entries)
+	}
+
+	// a new change of the same client is applied
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "fuga", Origin: alice("a3")}); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := server.GetHistory(ctx, &pb.GetHistoryRequest{Index: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if origin := entry.GetEntry().GetOrigin(); origin.GetUser() != "alice" || origin.GetHostname() != "laptop" || origin.GetRequestId() != "a3" {
+		t.Errorf("Expected the origin of the last copy, got %v", origin)
+	}
+}
+
+func TestWatchSkipsOwnCopies(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	client, stop := newTestClient(t, newTestServer(t, dir, service.NewMemoryClipboard()))
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	alice := &pb.CopyOrigin{Hostname: "laptop", User: "alice"}
+	bob := &pb.CopyOrigin{Hostname: "desktop", User: "bob"}
+
+	watch := func(origin *pb.CopyOrigin) pb.VimonadeService_WatchClient {
+		stream, err := client.Watch(ctx, &pb.WatchRequest{Origin: origin})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return stream
+	}
+
+	aliceWatch, bobWatch := watch(alice), watch(bob)
+	// let the server subscribe both watchers
+	time.Sleep(100 * time.Millisecond)
+
+	for _, req := range []*pb.CopyRequest{
+		{Value: "hoge", Origin: alice},
+		{Value: "fuga", Origin: bob},
+	} {
+		if _, err := client.Copy(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if event, err := bobWatch.Recv(); err != nil || event.GetValue() != "hoge" {
+		t.Errorf("Expected bob to see hoge, got %v (%v)", event, err)
+	}
+
+	// alice's own change was skipped
+	if event, err := aliceWatch.Recv(); err != nil || event.GetValue() != "fuga" {
+		t.Errorf("Expected alice to only see fuga, got %v (%v)", event, err)
+	}
+}
+
+func TestSensitiveCopy(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	cb := service.NewMemoryClipboard()
+	server := newTestServer(t, dir, cb)
+	ctx := context.Background()
+
+	assert := func(expected string) {
+		if text, _ := cb.Read(); text != expected {
+			t.Errorf("Expected: %q, got %q", expected, text)
+		}
+	}
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "hoge"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []string{"secret", "token"} {
+		if _, err := server.Copy(ctx, &pb.CopyRequest{Value: value, Sensitive: true, Ttl: 50}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert("token")
+	time.Sleep(200 * time.Millisecond)
+	// the secret copied in between isn't restored
+	assert("hoge")
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "secret", Ttl: 50}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "fuga"}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	// a newer copy doesn't expire
+	assert("fuga")
+
+	// copying the content of an expiring copy again, as another client does, keeps it
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "secret", Sensitive: true, Ttl: 50, Origin: &pb.CopyOrigin{User: "alice"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "secret", Origin: &pb.CopyOrigin{User: "bob"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	assert("secret")
+
+	res, err := server.ListHistory(ctx, &pb.ListHistoryRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range res.GetEntries() {
+		if entry.GetPreview() == "token" {
+			t.Errorf("Expected no sensitive entry in history, got %v", entry)
+		}
+	}
+
+	_, err = server.Copy(ctx, &pb.CopyRequest{Value: "secret", Register: "a", Sensitive: true})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a named register, got %v", err)
+	}
+}
+
+func TestCopyPasteTransforms(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	registers, err := service.NewDiskRegisterStore(filepath.Join(dir, "registers.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := service.NewDiskHistoryStore(filepath.Join(dir, "history.json"), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	copyTransform, err := lemon.ParsePipeline("strip-ansi,trim-trailing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pasteTransform, err := lemon.ParsePipeline("crlf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cb := service.NewMemoryClipboard()
+	transforms := &service.Transforms{Copy: copyTransform, Paste: pasteTransform}
+	server := service.NewVimonadeServerService(service.ServiceConfig{Clipboard: cb, Registers: registers, History: history, Watcher: service.NewWatcher(cb, 0, zap.NewNop()), Transforms: transforms}, zap.NewNop())
+	ctx := context.Background()
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "\x1b[31mred\x1b[0m  \nb\n"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if text, _ := cb.Read(); text != "red\nb\n" {
+		t.Errorf("Expected: %q, got %q", "red\nb\n", text)
+	}
+
+	res, err := server.Paste(ctx, &pb.PasteRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.GetValue() != "red\r\nb\r\n" || res.GetRegtype() != "V" {
+		t.Errorf("Expected: %q V, got %q %s", "red\r\nb\r\n", res.GetValue(), res.GetRegtype())
+	}
+
+	// the register type of a paste is inferred from the transformed text
+	lf, err := lemon.ParsePipeline("lf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transforms = &service.Transforms{Paste: lf}
+	server = service.NewVimonadeServerService(service.ServiceConfig{Clipboard: cb, Registers: registers, History: history, Watcher: service.NewWatcher(cb, 0, zap.NewNop()), Transforms: transforms}, zap.NewNop())
+
+	for _, req := range []*pb.CopyRequest{{Value: "mac\r"}, {Value: "mac\r", Register: "a"}} {
+		if _, err := server.Copy(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, req := range []*pb.PasteRequest{{}, {Register: "a"}, {Index: 1}} {
+		res, err := server.Paste(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.GetValue() != "mac\n" || res.GetRegtype() != "V" {
+			t.Errorf("%v: Expected: %q V, got %q %s", req, "mac\n", res.GetValue(), res.GetRegtype())
+		}
+	}
+}
+
+func TestConditionalPaste(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	server := newTestServer(t, dir, service.NewMemoryClipboard())
+	ctx := context.Background()
+
+	copied, err := server.Copy(ctx, &pb.CopyRequest{Value: "hoge\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := lemon.ContentVersion("V", "", []byte("hoge\n")); copied.GetVersion() != expected {
+		t.Errorf("Expected version %s, got %s", expected, copied.GetVersion())
+	}
+
+	res, err := server.Paste(ctx, &pb.PasteRequest{IfNoneMatch: copied.GetVersion()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !res.GetUnchanged() || res.GetValue() != "" || res.GetVersion() != copied.GetVersion() {
+		t.Errorf("Expected an unchanged paste without value, got %v", res)
+	}
+
+	if _, err := server.Copy(ctx, &pb.CopyRequest{Value: "fuga", Sensitive: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err = server.Paste(ctx, &pb.PasteRequest{IfNoneMatch: copied.GetVersion()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.GetUnchanged() || res.GetValue() != "fuga" || !res.GetSensitive() || res.GetVersion() == copied.GetVersion() {
+		t.Errorf("Expected the new sensitive value, got %v", res)
+	}
+}
+
+func TestCopyPasteBytes(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	server := newTestServer(t, dir, service.NewMemoryClipboard())
+	ctx := context.Background()
+
+	invalid := "caf\xe9\n"
+
+	for _, req := range []*pb.CopyRequest{
+		{Value: "  hoge\n\n"},
+		{Register: "a", Payloads: []*pb.Payload{{MimeType: "text/plain", Data: []byte(invalid)}}},
+	} {
+		if _, err := server.Copy(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// reload the registers and history from disk
+	server = newTestServer(t, dir, service.NewMemoryClipboard())
+
+	pasted := func(res *pb.PasteResponse) string {
+		if payloads := res.GetPayloads(); len(payloads) > 0 {
+			return string(payloads[0].GetData())
+		}
+
+		return res.GetValue()
+	}
+
+	for _, tc := range []struct {
+		req      *pb.PasteRequest
+		expected string
+	}{
+		{&pb.PasteRequest{Register: "a"}, invalid},
+		{&pb.PasteRequest{Index: 1}, invalid},
+		{&pb.PasteRequest{Index: 2}, "  hoge\n\n"},
+	} {
+		res, err := server.Paste(ctx, tc.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := pasted(res); got != tc.expected {
+			t.Errorf("%v: Expected: %q, got %q", tc.req, tc.expected, got)
+		}
+	}
+
+	res, err := server.GetHistory(ctx, &pb.GetHistoryRequest{Index: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.GetEntry().GetData()) != invalid || res.GetEntry().GetValue() != "caf\ufffd\n" {
+		t.Errorf("Expected the exact bytes and a valid value, got %v", res.GetEntry())
+	}
+}
+
+func TestHistory(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	server := newTestServer(t, dir, service.NewMemoryClipboard())
+	ctx := context.Background()
+
+	for _, value := range []string{"1", "2", "2", "3", "4"} {
+		if _, err := server.Copy(ctx, &pb.CopyRequest{Value: value}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := server.ListHistory(ctx, &pb.ListHistoryRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values []string
+	for _, entry := range list.GetEntries() {
+		values = append(values, entry.GetPreview())
+	}
+
+	if !reflect.DeepEqual(values, []string{"4", "3", "2"}) {
+		t.Errorf("Expected the 3 newest distinct entries, got %v", values)
+	}
+
+	res, err := server.Paste(ctx, &pb.PasteRequest{Index: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.GetValue() != "3" {
+		t.Errorf("Expected: %q, got %q", "3", res.GetValue())
+	}
+
+	if _, err := server.DeleteHistory(ctx, &pb.DeleteHistoryRequest{Index: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := server.GetHistory(ctx, &pb.GetHistoryRequest{Index: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.GetEntry().GetValue() != "3" || entry.GetEntry().GetSize() != 1 {
+		t.Errorf("Expected entry 1 to be %q after delete, got %v", "3", entry.GetEntry())
+	}
+
+	if _, err := server.DeleteHistory(ctx, &pb.DeleteHistoryRequest{All: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = server.GetHistory(ctx, &pb.GetHistoryRequest{Index: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound after clear, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+func (s *vimonadeServiceServer) SaveSnippet(ctx context.Context, message *pb.SaveSnippetRequest) (*pb.SaveSnippetResponse, error) {
+	if err := s.contextError(ctx); err != nil {
+		return &pb.SaveSnippetResponse{}, err
+	}
+
+	s.logger.Debug("SaveSnippet requested: name: " + message.GetName())
+
+	if err := s.snippets.Save(message.GetName(), &Snippet{
+		Value:     string(message.GetData()),
+		UpdatedAt: time.Now(),
+	}); err != nil {
+		return &pb.SaveSnippetResponse{}, snippetError(err)
+	}
+
+	return &pb.SaveSnippetResponse{}, nil
+}
+
+func (s *vimonadeServiceServer) GetSnippet(ctx context.Context, message *pb.GetSnippetRequest) (*pb.GetSnippetResponse, error) {
+	if err := s.contextError(ctx); err != nil {
+		return &pb.GetSnippetResponse{}, err
+	}
+
+	s.logger.Debug("GetSnippet requested: name: " + message.GetName())
+
+	snippet, err := s.snippets.Find(message.GetName())
+	if err != nil {
+		return &pb.GetSnippetResponse{}, snippetError(err)
+	}
+
+	return &pb.GetSnippetResponse{Snippet: toSnippet(message.GetName(), snippet)}, nil
+}
+
+func (s *vimonadeServiceServer) ListSnippets(ctx context.Context, message *pb.ListSnippetsRequest) (*pb.ListSnippetsResponse, error) {
+	if err := s.contextError(ctx); err != nil {
+		return &pb.ListSnippetsResponse{}, err
+	}
+
+	s.logger.Debug("ListSnippets requested")
+
+	names, err := s.snippets.List()
+	if err != nil {
+		return &pb.ListSnippetsResponse{}, snippetError(err)
+	}
+
+	res := &pb.ListSnippetsResponse{}
+
+	for _, name := range names {
+		snippet, err := s.snippets.Find(name)
+		if err == ErrSnippetNotFound {
+			// deleted in the meantime
+			continue
+		}
+		if err != nil {
+			return &pb.ListSnippetsResponse{}, snippetError(err)
+		}
+
+		// a listing only describes the snippets, which are pasted with GetSnippet
+		info := toSnippet(name, snippet)
+		info.Data = nil
+		info.Preview = lemon.Preview(validText(snippet.Value))
+
+		res.Snippets = append(res.Snippets, info)
+	}
+
+	return res, nil
+}
+
+func (s *vimonadeServiceServer) DeleteSnippet(ctx context.Context, message *pb.DeleteSnippetRequest) (*pb.DeleteSnippetResponse, error) {
+	if err := s.contextError(ctx); err != nil {
+		return &pb.DeleteSnippetResponse{}, err
+	}
+
+	s.logger.Debug("DeleteSnippet requested: name: " + message.GetName())
+
+	if err := s.snippets.Delete(message.GetName()); err != nil {
+		return &pb.DeleteSnippetResponse{}, snippetError(err)
+	}
+
+	return &pb.DeleteSnippetResponse{}, nil
+}
+
+func toSnippet(name string, snippet *Snippet) *pb.Snippet {
+	return &pb.Snippet{
+		Name:      name,
+		Data:      []byte(snippet.Value),
+		UpdatedAt: snippet.UpdatedAt.Unix(),
+		Size:      uint64(len(snippet.Value)),
+	}
+}
+
+// snippetError converts a SnippetStore error to a gRPC status
+func snippetError(err error) error {
+	switch err {
+	case ErrSnippetNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case ErrSnippetName:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return logError(status.Errorf(codes.Internal, "cannot access snippets: %v", err))
+	}
+}
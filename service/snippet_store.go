@@ -0,0 +1,195 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const maxSnippetNameLength = 128
+
+var (
+	// ErrSnippetNotFound is returned when no snippet has a name
+	ErrSnippetNotFound = errors.New("snippet not found")
+	// ErrSnippetName is returned for empty names or names with spaces or control characters
+	ErrSnippetName = errors.New("invalid snippet name")
+)
+
+// SnippetStore is an interface to store named snippets
+type SnippetStore interface {
+	// Save creates or replaces a snippet
+	Save(name string, snippet *Snippet) error
+	// Find returns a snippet
+	Find(name string) (*Snippet, error)
+	// List returns the names of all snippets, sorted
+	List() ([]string, error)
+	// Delete removes a snippet
+	Delete(name string) error
+}
+
+// Snippet is a named clip kept until it's deleted
+type Snippet struct {
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MarshalJSON saves values which aren't valid UTF-8 as base64 encoded data
+func (s *Snippet) MarshalJSON() ([]byte, error) {
+	type snippet Snippet
+
+	value, data := splitText(s.Value)
+
+	return json.Marshal(struct {
+		*snippet
+		Value string `json:"value"`
+		Data  []byte `json:"data,omitempty"`
+	}{(*snippet)(s), value, data})
+}
+
+// UnmarshalJSON decodes snippets saved by MarshalJSON
+func (s *Snippet) UnmarshalJSON(b []byte) error {
+	type snippet Snippet
+
+	decoded := struct {
+		*snippet
+		Data []byte `json:"data"`
+	}{snippet: (*snippet)(s)}
+
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+
+	s.Value = joinText(s.Value, decoded.Data)
+
+	return nil
+}
+
+// ValidSnippetName reports whether name can name a snippet
+func ValidSnippetName(name string) bool {
+	if name == "" || len(name) > maxSnippetNameLength {
+		return false
+	}
+
+	return strings.IndexFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r) || r == unicode.ReplacementChar
+	}) < 0
+}
+
+// DiskSnippetStore keeps snippets in memory and persists them to a json file
+type DiskSnippetStore struct {
+	mutex    sync.RWMutex
+	path     string
+	snippets map[string]*Snippet
+}
+
+// NewDiskSnippetStore returns a new DiskSnippetStore loaded from path
+func NewDiskSnippetStore(path string) (*DiskSnippetStore, error) {
+	store := &DiskSnippetStore{
+		path:     path,
+		snippets: make(map[string]*Snippet),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read snippets: %s", err)
+	}
+
+	if err := json.Unmarshal(b, &store.snippets); err != nil {
+		return nil, fmt.Errorf("cannot decode snippets: %s", err)
+	}
+
+	return store, nil
+}
+
+// Save creates or replaces a snippet and writes all snippets to disk
+func (store *DiskSnippetStore) Save(name string, snippet *Snippet) error {
+	if !ValidSnippetName(name) {
+		return ErrSnippetName
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	snippets := store.copySnippets()
+	snippets[name] = snippet
+
+	return store.save(snippets)
+}
+
+// Find returns a snippet
+func (store *DiskSnippetStore) Find(name string) (*Snippet, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	snippet, ok := store.snippets[name]
+	if !ok {
+		return nil, ErrSnippetNotFound
+	}
+
+	return snippet, nil
+}
+
+// List returns the names of all snippets, sorted
+func (store *DiskSnippetStore) List() ([]string, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	names := make([]string, 0, len(store.snippets))
+	for name := range store.snippets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Delete removes a snippet and writes the others to disk
+func (store *DiskSnippetStore) Delete(name string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, ok := store.snippets[name]; !ok {
+		return ErrSnippetNotFound
+	}
+
+	snippets := store.copySnippets()
+	delete(snippets, name)
+
+	return store.save(snippets)
+}
+
+func (store *DiskSnippetStore) copySnippets() map[string]*Snippet {
+	snippets := make(map[string]*Snippet, len(store.snippets)+1)
+	for name, snippet := range store.snippets {
+		snippets[name] = snippet
+	}
+
+	return snippets
+}
+
+// save persists snippets, which only replace the snippets in memory once they're written
+func (store *DiskSnippetStore) save(snippets map[string]*Snippet) error {
+	b, err := json.Marshal(snippets)
+	if err != nil {
+		return fmt.Errorf("cannot encode snippets: %s", err)
+	}
+
+	if err := writeFile(store.path, b); err != nil {
+		return fmt.Errorf("cannot write snippets: %s", err)
+	}
+
+	store.snippets = snippets
+
+	return nil
+}
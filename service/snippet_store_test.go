@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/service"
+)
+
+func TestDiskSnippetStore(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "snippets.json")
+
+	store, err := service.NewDiskSnippetStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, value := range map[string]string{"trailer": "Signed-off-by: hoge\n", "bytes": "caf\xe9"} {
+		if err := store.Save(name, &service.Snippet{Value: value}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"", "a b", "a\tb"} {
+		if err := store.Save(name, &service.Snippet{}); err != service.ErrSnippetName {
+			t.Errorf("%q: Expected ErrSnippetName, got %v", name, err)
+		}
+	}
+
+	// snippets are persisted
+	store, err = service.NewDiskSnippetStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 2 || names[0] != "bytes" || names[1] != "trailer" {
+		t.Errorf("Expected [bytes trailer], got %v", names)
+	}
+
+	if snippet, err := store.Find("bytes"); err != nil || snippet.Value != "caf\xe9" {
+		t.Errorf("Expected: %q, got %v (%v)", "caf\xe9", snippet, err)
+	}
+
+	if err := store.Delete("bytes"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Find("bytes"); err != service.ErrSnippetNotFound {
+		t.Errorf("Expected ErrSnippetNotFound, got %v", err)
+	}
+
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("Expected only the snippets file, got %d files", len(files))
+	}
+
+	// a failed save leaves the snippets as they were
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Save("db", &service.Snippet{Value: "postgres://localhost/db"}); err == nil {
+		t.Error("Expected an error when the snippets can't be written")
+	}
+
+	if err := store.Delete("trailer"); err == nil {
+		t.Error("Expected an error when the snippets can't be written")
+	}
+
+	if names, _ := store.List(); len(names) != 1 || names[0] != "trailer" {
+		t.Errorf("Expected [trailer], got %v", names)
+	}
+}
+
+func TestSnippets(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	server := newTestServer(t, dir, service.NewMemoryClipboard())
+	ctx := context.Background()
+
+	if _, err := server.SaveSnippet(ctx, &pb.SaveSnippetRequest{Name: "db", Data: []byte("postgres://localhost/db")}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := server.GetSnippet(ctx, &pb.GetSnippetRequest{Name: "db"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.GetSnippet().GetData()) != "postgres://localhost/db" || res.GetSnippet().GetSize() != 23 {
+		t.Errorf("Expected the saved snippet, got %v", res.GetSnippet())
+	}
+
+	list, err := server.ListSnippets(ctx, &pb.ListSnippetsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.GetSnippets()) != 1 || list.GetSnippets()[0].GetName() != "db" {
+		t.Fatalf("Expected the db snippet, got %v", list.GetSnippets())
+	}
+
+	// a listing leaves the data out
+	if snippet := list.GetSnippets()[0]; snippet.GetData() != nil || snippet.GetPreview() != "postgres://localhost/db" || snippet.GetSize() != 23 || snippet.GetUpdatedAt() == 0 {
+		t.Errorf("Expected the db snippet without data, got %v", snippet)
+	}
+
+	if _, err := server.DeleteSnippet(ctx, &pb.DeleteSnippetRequest{Name: "db"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.GetSnippet(ctx, &pb.GetSnippetRequest{Name: "db"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	if _, err := server.SaveSnippet(ctx, &pb.SaveSnippetRequest{Name: "a b"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+)
+
+const (
+	maxCopySize = 1 << 28
+	chunkSize   = 1 << 16
+	// maxPasteSize is the largest content answered by Paste,
+	// below the default 4MB message limit of gRPC clients
+	maxPasteSize = 1<<22 - chunkSize
+)
+
+func (s *vimonadeServiceServer) CopyStream(stream pb.VimonadeService_CopyStreamServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "cannot receive copy info"))
+	}
+
+	info := req.GetInfo()
+	if info == nil {
+		return logError(status.Errorf(codes.InvalidArgument, "first message must be the copy info"))
+	}
+
+	if len(info.GetPayloads()) > 1 {
+		return logError(status.Errorf(codes.InvalidArgument, "cannot stream more than one payload"))
+	}
+
+	s.logger.Debug("receive a streamed copy for register: " + info.GetRegister())
+
+	data := bytes.Buffer{}
+
+	for {
+		err := s.contextError(stream.Context())
+		if err != nil {
+			return err
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "cannot receive chunk data: %v", err))
+		}
+
+		chunk := req.GetChunkData()
+
+		if data.Len()+len(chunk) > maxCopySize {
+			return logError(status.Errorf(codes.ResourceExhausted, "copy is too large: > %d", maxCopySize))
+		}
+
+		data.Write(chunk)
+	}
+
+	s.logger.Debug(fmt.Sprintf("received a streamed copy with size %d", data.Len()))
+
+	message := &pb.CopyRequest{
+		Register:  info.GetRegister(),
+		Regtype:   info.GetRegtype(),
+		Selection: info.GetSelection(),
+		Channel:   info.GetChannel(),
+		Sensitive: info.GetSensitive(),
+		Ttl:       info.GetTtl(),
+		Origin:    info.GetOrigin(),
+	}
+
+	if payloads := info.GetPayloads(); len(payloads) == 1 {
+		message.Payloads = []*pb.Payload{{MimeType: payloads[0].GetMimeType(), Data: data.Bytes()}}
+	} else {
+		message.Value = data.String()
+	}
+
+	res, err := s.Copy(stream.Context(), message)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(res)
+}
+
+// pasteSize returns the size of the content of a paste
+func pasteSize(res *pb.PasteResponse) int {
+	size := len(res.GetValue())
+
+	for _, payload := range res.GetPayloads() {
+		size += len(payload.GetData())
+	}
+
+	return size
+}
+
+func (s *vimonadeServiceServer) PasteStream(message *pb.PasteRequest, stream pb.VimonadeService_PasteStreamServer) error {
+	res, err := s.pasteVersioned(stream.Context(), message)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+
+	info := &pb.PasteResponse{
+		Regtype:   res.GetRegtype(),
+		Version:   res.GetVersion(),
+		Unchanged: res.GetUnchanged(),
+		Sensitive: res.GetSensitive(),
+		CopiedAt:  res.GetCopiedAt(),
+	}
+
+	if payloads := res.GetPayloads(); len(payloads) > 0 {
+		info.Payloads = []*pb.Payload{{MimeType: payloads[0].GetMimeType()}}
+		data = payloads[0].GetData()
+	} else {
+		data = []byte(res.GetValue())
+	}
+
+	size := len(data)
+
+	if err := stream.Send(&pb.PasteChunk{Data: &pb.PasteChunk_Info{Info: info}}); err != nil {
+		return logError(status.Errorf(codes.Unknown, "cannot send paste info: %v", err))
+	}
+
+	for len(data) > 0 {
+		n := chunkSize
+		if n > len(data) {
+			n = len(data)
+		}
+
+		if err := stream.Send(&pb.PasteChunk{Data: &pb.PasteChunk_ChunkData{ChunkData: data[:n]}}); err != nil {
+			return logError(status.Errorf(codes.Unknown, "cannot send chunk data: %v", err))
+		}
+
+		data = data[n:]
+	}
+
+	s.logger.Debug(fmt.Sprintf("sent a streamed paste with size %d", size))
+
+	return nil
+}
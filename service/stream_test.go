@@ -0,0 +1,220 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/service"
+)
+
+// newTestClient serves srv over an in-memory connection
+func newTestClient(t *testing.T, srv pb.VimonadeServiceServer) (pb.VimonadeServiceClient, func()) {
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer()
+	pb.RegisterVimonadeServiceServer(server, srv)
+
+	go server.Serve(listener)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pb.NewVimonadeServiceClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestCopyPasteStream(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	client, stop := newTestClient(t, newTestServer(t, dir, service.NewMemoryClipboard()))
+	defer stop()
+
+	ctx := context.Background()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	watch, err := client.Watch(watchCtx, &pb.WatchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// let the server subscribe the watcher
+	time.Sleep(100 * time.Millisecond)
+
+	// larger than the default 4MB message limit
+	text := bytes.Repeat([]byte("vimonade\n"), 1<<19)
+
+	stream, err := client.CopyStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stream.Send(&pb.CopyChunk{Data: &pb.CopyChunk_Info{Info: &pb.CopyRequest{Regtype: "V"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < len(text); i += 1 << 16 {
+		if err := stream.Send(&pb.CopyChunk{Data: &pb.CopyChunk_ChunkData{ChunkData: text[i : i+1<<16]}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the content is only answered by PasteStream, not built for a message too large to send
+	if _, err := client.Paste(ctx, &pb.PasteRequest{}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted for a large Paste, got %v", err)
+	}
+
+	if _, err := client.GetHistory(ctx, &pb.GetHistoryRequest{Index: 1}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted for a large history entry, got %v", err)
+	}
+
+	// watchers and history listings only get a digest or a preview
+	event, err := watch.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event.GetValue() != "" || event.GetDigest() == "" || event.GetSize() != uint64(len(text)) {
+		t.Errorf("Expected an event without value, got %d bytes, digest %q, size %d", len(event.GetValue()), event.GetDigest(), event.GetSize())
+	}
+
+	history, err := client.ListHistory(ctx, &pb.ListHistoryRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entries := history.GetEntries(); len(entries) != 1 || entries[0].GetValue() != "" || entries[0].GetPreview() == "" || entries[0].GetSize() != uint64(len(text)) {
+		t.Errorf("Expected the large copy listed without its value, got %d entries", len(entries))
+	}
+
+	paste, err := client.PasteStream(ctx, &pb.PasteRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := paste.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.GetInfo().GetRegtype() != "V" {
+		t.Errorf("Expected regtype %q, got %q", "V", info.GetInfo().GetRegtype())
+	}
+
+	got := bytes.Buffer{}
+
+	for {
+		chunk, err := paste.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got.Write(chunk.GetChunkData())
+	}
+
+	if !bytes.Equal(got.Bytes(), text) {
+		t.Errorf("Expected %d bytes, got %d", len(text), got.Len())
+	}
+
+	// streamed copies keep their channel and origin
+	stream, err = client.CopyStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	origin := &pb.CopyOrigin{Hostname: "laptop", User: "alice", RequestId: "a1"}
+	if err := stream.Send(&pb.CopyChunk{Data: &pb.CopyChunk_Info{Info: &pb.CopyRequest{Channel: "pair", Origin: origin}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stream.Send(&pb.CopyChunk{Data: &pb.CopyChunk_ChunkData{ChunkData: []byte("hoge")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pasted, err := client.Paste(ctx, &pb.PasteRequest{Channel: "pair"}); err != nil || pasted.GetValue() != "hoge" {
+		t.Errorf("Expected hoge in the channel, got %v (%v)", pasted, err)
+	}
+
+	// the origin identifies a copy delivered twice
+	stream, err = client.CopyStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stream.Send(&pb.CopyChunk{Data: &pb.CopyChunk_Info{Info: &pb.CopyRequest{Channel: "pair", Origin: origin}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stream.Send(&pb.CopyChunk{Data: &pb.CopyChunk_ChunkData{ChunkData: []byte("fuga")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if again, err := stream.CloseAndRecv(); err != nil || again.GetVersion() != res.GetVersion() {
+		t.Errorf("Expected the version of the first delivery %s, got %v (%v)", res.GetVersion(), again, err)
+	}
+}
+
+func TestSendOffset(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	store, err := service.NewDiskFileStore(dir, service.FileStoreConfig{MaxSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cb := service.NewMemoryClipboard()
+	client, stop := newTestClient(t, service.NewVimonadeServerService(service.ServiceConfig{FileStore: store, Clipboard: cb, Watcher: service.NewWatcher(cb, 0, zap.NewNop())}, zap.NewNop()))
+	defer stop()
+
+	stream, err := client.Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an offset would skip the size limit without an upload to resume
+	if err := stream.Send(&pb.SendFileRequest{Data: &pb.SendFileRequest_Info{Info: &pb.FileInfo{Name: "large.txt", Offset: 1 << 20}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an offset without upload id, got %v", err)
+	}
+
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected no file to be stored, got %d files", len(files))
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+// Transforms holds the text transform pipelines run by the server
+type Transforms struct {
+	// Copy runs on copied text before it reaches the clipboard
+	Copy lemon.Pipeline
+	// Paste runs on pasted text
+	Paste lemon.Pipeline
+}
+
+// copy runs the plain text payloads of a copy through the copy pipeline
+func (t *Transforms) copy(payloads []*Payload) ([]*Payload, error) {
+	if t == nil || len(t.Copy) == 0 {
+		return payloads, nil
+	}
+
+	transformed := make([]*Payload, len(payloads))
+
+	for i, payload := range payloads {
+		transformed[i] = payload
+
+		if !lemon.IsText(payload.MimeType) {
+			continue
+		}
+
+		text, err := t.Copy.Apply(string(payload.Data))
+		if err != nil {
+			return nil, err
+		}
+
+		transformed[i] = &Payload{MimeType: payload.MimeType, Data: []byte(text)}
+	}
+
+	return transformed, nil
+}
+
+// paste runs pasted text through the paste pipeline
+func (t *Transforms) paste(text string) (string, error) {
+	if t == nil {
+		return text, nil
+	}
+
+	return t.Paste.Apply(text)
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/jrc2139/vimonade/api"
+)
+
+func (s *vimonadeServiceServer) UploadStatus(ctx context.Context, message *pb.UploadStatusRequest) (*pb.UploadStatusResponse, error) {
+	if err := s.contextError(ctx); err != nil {
+		return &pb.UploadStatusResponse{}, err
+	}
+
+	s.logger.Debug("UploadStatus requested: upload id: " + message.GetUploadId())
+
+	offset, err := s.fileStore.UploadOffset(message.GetUploadId())
+	if err != nil {
+		return &pb.UploadStatusResponse{}, fileError(err)
+	}
+
+	return &pb.UploadStatusResponse{
+		UploadId: message.GetUploadId(),
+		Offset:   uint64(offset),
+	}, nil
+}
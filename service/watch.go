@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/jrc2139/vimonade/api"
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+func (s *vimonadeServiceServer) Watch(message *pb.WatchRequest, stream pb.VimonadeService_WatchServer) error {
+	s.logger.Debug("Watch requested: register: " + message.GetRegister())
+
+	channel, err := s.channels.Resolve(message.GetChannel())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if channel != "" {
+		s.logger.Info(fmt.Sprintf("Channel %s joined: %d members", channel, s.channels.Join(channel)))
+
+		defer func() {
+			s.logger.Info(fmt.Sprintf("Channel %s left: %d members", channel, s.channels.Leave(channel)))
+		}()
+	}
+
+	client := fromPbOrigin(message.GetOrigin())
+
+	sub := s.watcher.Subscribe()
+	defer s.watcher.Unsubscribe(sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			s.logger.Debug("Watch ended: " + stream.Context().Err().Error())
+			return nil
+		case event := <-sub.Events():
+			if event.Channel != channel || !watches(message.GetRegister(), event.Register) {
+				continue
+			}
+
+			// a client syncing its clipboard would copy its own change back
+			if event.Client.sameClient(client) {
+				continue
+			}
+
+			if err := stream.Send(toClipboardEvent(event, message.GetDigestOnly(), sub.Dropped())); err != nil {
+				return logError(status.Errorf(codes.Unknown, "cannot send event: %v", err))
+			}
+		}
+	}
+}
+
+// watches reports whether a watcher of register is interested in a change of changed
+func watches(register, changed string) bool {
+	if register == "" {
+		return true
+	}
+
+	if lemon.IsClipboardRegister(register) {
+		return lemon.IsClipboardRegister(changed)
+	}
+
+	return register == changed
+}
+
+func toClipboardEvent(event *Event, digestOnly bool, dropped uint64) *pb.ClipboardEvent {
+	res := &pb.ClipboardEvent{
+		Register:  event.Register,
+		Origin:    event.Origin,
+		Timestamp: event.Time.UnixNano() / 1e6,
+		Size:      uint64(len(event.Value)),
+		Regtype:   withRegtype(event.Value, event.Regtype),
+		Dropped:   dropped,
+		Sensitive: event.Sensitive,
+		Channel:   event.Channel,
+		Client:    toPbOrigin(event.Client),
+	}
+
+	if event.Sensitive {
+		return res
+	}
+
+	digest := sha256.Sum256([]byte(event.Value))
+	res.Digest = hex.EncodeToString(digest[:])
+
+	// a large copy would exceed the message limit of watching clients, ending their stream
+	if !digestOnly && len(event.Value) <= maxPasteSize {
+		res.Value = validText(event.Value)
+	}
+
+	return res
+}
+
+// peerAddr returns the address of the client of a request
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	return p.Addr.String()
+}
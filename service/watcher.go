@@ -0,0 +1,226 @@
+package service
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jrc2139/vimonade/lemon"
+)
+
+const (
+	// subscriptionBuffer is the number of events queued for a slow subscriber
+	subscriptionBuffer = 16
+	// HostOrigin is the origin of changes made on the server desktop
+	HostOrigin = "host"
+)
+
+// Event describes a clipboard change
+type Event struct {
+	Register string
+	Value    string
+	Regtype  string
+	Origin   string
+	Time     time.Time
+	// Sensitive events are sent without value nor digest
+	Sensitive bool
+	// Channel is the shared channel of the change, empty for the system clipboard
+	Channel string
+	// Client is the client which made the copy, nil for changes made on the host
+	Client *CopyOrigin
+}
+
+// Subscription receives the events of a Watcher
+type Subscription struct {
+	// dropped comes first to be 64-bit aligned for atomic operations on 32-bit platforms
+	dropped uint64
+	events  chan *Event
+}
+
+// Events returns the channel receiving events
+func (sub *Subscription) Events() <-chan *Event {
+	return sub.events
+}
+
+// Dropped returns and resets the number of events dropped since the last call
+func (sub *Subscription) Dropped() uint64 {
+	return atomic.SwapUint64(&sub.dropped, 0)
+}
+
+// Watcher fans clipboard events out to subscribers.
+// It polls the clipboard for changes made on the host while anyone is subscribed,
+// or all the time once started.
+type Watcher struct {
+	mutex       sync.Mutex
+	clipboard   Clipboard
+	interval    time.Duration
+	logger      *zap.Logger
+	subscribers map[*Subscription]struct{}
+	lastSeen    string
+	// seenAt is when the content of lastSeen was copied or changed on the host,
+	// zero when it was already there as polling started
+	seenAt time.Time
+	// generation counts published events, so polls racing with a copy are ignored
+	generation uint64
+	started    bool
+	stop       chan struct{}
+}
+
+// NewWatcher returns a new Watcher polling clipboard every interval
+func NewWatcher(clipboard Clipboard, interval time.Duration, logger *zap.Logger) *Watcher {
+	return &Watcher{
+		clipboard:   clipboard,
+		interval:    interval,
+		logger:      logger,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe returns a new Subscription, starting to poll the clipboard if it's the first one
+func (w *Watcher) Subscribe() *Subscription {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	sub := &Subscription{events: make(chan *Event, subscriptionBuffer)}
+	w.subscribers[sub] = struct{}{}
+
+	w.startPolling()
+
+	return sub
+}
+
+// Start polls the clipboard until the process exits, even without subscribers,
+// so that the changes made on the host are dated when they happen
+func (w *Watcher) Start() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.started = true
+	w.startPolling()
+}
+
+// SeenAt returns when text was copied or changed on the host,
+// or the zero time if it isn't the last content seen or it's unknown
+func (w *Watcher) SeenAt(text string) time.Time {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if text != w.lastSeen {
+		return time.Time{}
+	}
+
+	return w.seenAt
+}
+
+func (w *Watcher) startPolling() {
+	if w.stop != nil || w.interval <= 0 {
+		return
+	}
+
+	// a change made while nothing was polling can't be dated
+	if text, err := w.clipboard.Read(); err == nil && text != w.lastSeen {
+		w.lastSeen = text
+		w.seenAt = time.Time{}
+	}
+
+	w.stop = make(chan struct{})
+	go w.poll(w.stop)
+}
+
+// Unsubscribe removes a Subscription, stopping to poll the clipboard if it was the last one
+func (w *Watcher) Unsubscribe(sub *Subscription) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if _, ok := w.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(w.subscribers, sub)
+
+	if len(w.subscribers) == 0 && !w.started && w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+}
+
+// Publish sends an event to every subscriber.
+// A subscriber whose queue is full loses its oldest event rather than blocking the others.
+func (w *Watcher) Publish(event *Event) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.publish(event)
+}
+
+func (w *Watcher) publish(event *Event) {
+	w.generation++
+
+	if lemon.IsClipboardRegister(event.Register) {
+		event.Register = "+"
+
+		// channels are isolated from the host clipboard
+		if event.Channel == "" {
+			w.lastSeen = event.Value
+			w.seenAt = event.Time
+		}
+	}
+
+	for sub := range w.subscribers {
+		select {
+		case sub.events <- event:
+			continue
+		default:
+		}
+
+		select {
+		case <-sub.events:
+			atomic.AddUint64(&sub.dropped, 1)
+		default:
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			atomic.AddUint64(&sub.dropped, 1)
+		}
+	}
+}
+
+// poll publishes the changes made to the clipboard outside of vimonade until stop is closed
+func (w *Watcher) poll(stop chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		w.mutex.Lock()
+		generation := w.generation
+		w.mutex.Unlock()
+
+		text, err := w.clipboard.Read()
+		if err != nil {
+			w.logger.Debug("Watching clipboard failed: " + err.Error())
+			continue
+		}
+
+		w.mutex.Lock()
+		if generation == w.generation && text != w.lastSeen {
+			w.publish(&Event{
+				Register: "+",
+				Value:    text,
+				Regtype:  lemon.InferRegtype(text),
+				Origin:   HostOrigin,
+				Time:     time.Now(),
+			})
+		}
+		w.mutex.Unlock()
+	}
+}
@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jrc2139/vimonade/service"
+)
+
+func TestWatcherSlowSubscriber(t *testing.T) {
+	watcher := service.NewWatcher(service.NewMemoryClipboard(), 0, zap.NewNop())
+
+	sub := watcher.Subscribe()
+	defer watcher.Unsubscribe(sub)
+
+	for i := 0; i < 20; i++ {
+		watcher.Publish(&service.Event{Register: "a", Value: fmt.Sprint(i)})
+	}
+
+	// the oldest events are dropped, the newest are kept
+	event := <-sub.Events()
+	if event.Value != "4" {
+		t.Errorf("Expected the oldest kept event to be %q, got %q", "4", event.Value)
+	}
+
+	if dropped := sub.Dropped(); dropped != 4 {
+		t.Errorf("Expected 4 dropped events, got %d", dropped)
+	}
+
+	if dropped := sub.Dropped(); dropped != 0 {
+		t.Errorf("Expected the dropped count to be reset, got %d", dropped)
+	}
+}
+
+func TestWatcherHostChanges(t *testing.T) {
+	cb := service.NewMemoryClipboard()
+	watcher := service.NewWatcher(cb, 10*time.Millisecond, zap.NewNop())
+
+	sub := watcher.Subscribe()
+	defer watcher.Unsubscribe(sub)
+
+	// copies through vimonade aren't reported twice
+	watcher.Publish(&service.Event{Value: "copied", Origin: "127.0.0.1:1234"})
+	if err := cb.Write("copied"); err != nil {
+		t.Fatal(err)
+	}
+
+	if event := <-sub.Events(); event.Origin != "127.0.0.1:1234" || event.Register != "+" {
+		t.Errorf("Expected the copy event, got %+v", event)
+	}
+
+	if err := cb.Write("host"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-sub.Events():
+		if event.Value != "host" || event.Origin != service.HostOrigin {
+			t.Errorf("Expected a host event, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the host change to be detected")
+	}
+}
+
+func TestWatcherSeenAt(t *testing.T) {
+	cb := service.NewMemoryClipboard()
+	if err := cb.Write("before"); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher := service.NewWatcher(cb, 10*time.Millisecond, zap.NewNop())
+	watcher.Start()
+
+	// the content found when polling started can't be dated
+	if seen := watcher.SeenAt("before"); !seen.IsZero() {
+		t.Errorf("Expected no time for the initial content, got %v", seen)
+	}
+
+	changed := time.Now()
+	if err := cb.Write("host"); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	// a host change is dated when the poll detects it, not when it's read later
+	seen := watcher.SeenAt("host")
+	if seen.Before(changed) || seen.After(changed.Add(50*time.Millisecond)) {
+		t.Errorf("Expected the host change to be dated around %v, got %v", changed, seen)
+	}
+
+	if !watcher.SeenAt("before").IsZero() {
+		t.Error("Expected no time for a replaced content")
+	}
+
+	copied := time.Now().Add(-time.Minute)
+	watcher.Publish(&service.Event{Value: "copied", Time: copied})
+
+	if seen := watcher.SeenAt("copied"); !seen.Equal(copied) {
+		t.Errorf("Expected the time of the copy %v, got %v", copied, seen)
+	}
+}